@@ -11,12 +11,14 @@ import (
 	"time"
 
 	"github.com/heathcliff26/minecraft-exporter/pkg/config"
+	"github.com/heathcliff26/minecraft-exporter/pkg/probe"
 	"github.com/heathcliff26/minecraft-exporter/pkg/rcon"
 	"github.com/heathcliff26/minecraft-exporter/pkg/save"
 	"github.com/heathcliff26/minecraft-exporter/pkg/version"
 	"github.com/heathcliff26/promremote/v2/promremote"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+	dto "github.com/prometheus/client_model/go"
 )
 
 var (
@@ -38,6 +40,151 @@ func ServerRootHandler(w http.ResponseWriter, r *http.Request) {
 	fmt.Fprint(w, "<html><body><h1>Welcome to minecraft-exporter</h1>Click <a href='/metrics'>here</a> to see metrics.</body></html>")
 }
 
+// Drops metrics that fail to encode, e.g. players that could not be loaded.
+// The remote write client aborts on the first invalid metric, so it would otherwise not send any metrics.
+type validMetricsCollector struct {
+	prometheus.Collector
+}
+
+// Implements the Collect function for prometheus.Collector
+func (c validMetricsCollector) Collect(ch chan<- prometheus.Metric) {
+	metrics := make(chan prometheus.Metric)
+	go func() {
+		c.Collector.Collect(metrics)
+		close(metrics)
+	}()
+
+	for metric := range metrics {
+		if metric.Write(&dto.Metric{}) != nil {
+			continue
+		}
+		ch <- metric
+	}
+}
+
+// Register the collectors of all servers.
+// The metrics of each server are labeled with its instance, as the registry requires unique metric descriptions.
+// Returns a function that stops the collectors of all servers, they are already stopped when an error is returned.
+func registerServers(reg prometheus.Registerer, cfg config.Config) (func(), error) {
+	var cleanup []func()
+	closeAll := func() {
+		for _, f := range cleanup {
+			f()
+		}
+	}
+
+	for _, server := range cfg.GetServers() {
+		serverReg := prometheus.WrapRegistererWith(prometheus.Labels{"server": server.Instance}, reg)
+		closeServer, err := registerServerCollectors(serverReg, cfg, server)
+		cleanup = append(cleanup, closeServer)
+		if err != nil {
+			closeAll()
+			return func() {}, fmt.Errorf("failed to create collectors for instance %s: %w", server.Instance, err)
+		}
+	}
+
+	return closeAll, nil
+}
+
+// Register all collectors for the given server.
+// Returns a function that stops the background tasks and closes the connections of the collectors.
+func registerServerCollectors(reg prometheus.Registerer, cfg config.Config, server config.ServerConfig) (func(), error) {
+	var cleanup []func()
+	closeAll := func() {
+		for _, f := range cleanup {
+			f()
+		}
+	}
+
+	primary := server.Worlds.Primary()
+
+	sc, err := save.NewSaveCollector(primary.Path, server.Instance, cfg.ReduceMetrics)
+	if err != nil {
+		return closeAll, fmt.Errorf("failed to create save collector: %w", err)
+	}
+	sc.Items = cfg.Save.Items
+	sc.StatNamespaces = cfg.Save.StatNamespaces
+	sc.AdvancementDetails = cfg.Save.AdvancementDetails
+	sc.Workers = cfg.Save.Workers
+	sc.SetPlayerCacheSize(cfg.Save.PlayerCacheSize)
+	reg.MustRegister(sc)
+
+	scc, err := save.NewScoreboardCollector(primary.Path, server.Instance)
+	if err != nil {
+		return closeAll, fmt.Errorf("failed to create scoreboard collector: %w", err)
+	}
+	scc.Include = cfg.Save.Scoreboard.Include
+	scc.Exclude = cfg.Save.Scoreboard.Exclude
+	reg.MustRegister(scc)
+
+	for _, world := range server.Worlds {
+		// Label all metrics of the world with its name
+		worldReg := prometheus.WrapRegistererWith(prometheus.Labels{"world": world.Name}, reg)
+
+		wc, err := save.NewWorldCollector(world.Path, server.Instance)
+		if err != nil {
+			return closeAll, fmt.Errorf("failed to create world collector for world %s: %w", world.Name, err)
+		}
+		worldReg.MustRegister(wc)
+
+		dc, err := save.NewDiskUsageCollector(world.Path, server.Instance)
+		if err != nil {
+			return closeAll, fmt.Errorf("failed to create disk usage collector for world %s: %w", world.Name, err)
+		}
+		worldReg.MustRegister(dc)
+
+		wdc, err := save.NewWorldDataCollector(world.Path, server.Instance)
+		if err != nil {
+			return closeAll, fmt.Errorf("failed to create world data collector for world %s: %w", world.Name, err)
+		}
+		worldReg.MustRegister(wdc)
+
+		// The region files are decoded once and shared by the chunk scan, the block entities and the container audit
+		var auditItems []string
+		if cfg.Save.ContainerAudit.Enable {
+			auditItems = cfg.Save.ContainerAudit.Items
+		}
+		regions, err := save.NewRegionScanner(world.Path, auditItems)
+		if err != nil {
+			return closeAll, fmt.Errorf("failed to create region scanner for world %s: %w", world.Name, err)
+		}
+
+		if cfg.Save.ScanEntities {
+			ec := save.NewEntityCollector(regions, server.Instance)
+			ec.TopChunks = cfg.Save.TopChunks
+			worldReg.MustRegister(ec)
+		}
+
+		if cfg.Save.ScanChunks {
+			cc := save.NewChunkCollector(regions, server.Instance)
+			cc.InhabitedTimeGrid = cfg.Save.InhabitedTimeGrid
+			worldReg.MustRegister(cc)
+		}
+
+		if cfg.Save.ContainerAudit.Enable {
+			cac := save.NewContainerAuditCollector(regions, server.Instance)
+			cac.Run(cfg.Save.ContainerAudit.Interval)
+			cleanup = append(cleanup, cac.Stop)
+			worldReg.MustRegister(cac)
+		}
+	}
+
+	if server.RCON.Enable {
+		rc, err := rcon.NewRCONCollector(server)
+		if err != nil {
+			return closeAll, fmt.Errorf("failed to create rcon collector: %w", err)
+		}
+		cleanup = append(cleanup, func() { _ = rc.Close() })
+		reg.MustRegister(rc)
+		err = sc.SetRCONClient(rc.Client())
+		if err != nil {
+			return closeAll, fmt.Errorf("failed to read the minecraft version from save: %w", err)
+		}
+	}
+
+	return closeAll, nil
+}
+
 func main() {
 	flag.Parse()
 
@@ -54,36 +201,25 @@ func main() {
 
 	reg := prometheus.NewRegistry()
 
-	sc, err := save.NewSaveCollector(cfg.WorldDir, cfg.Instance, cfg.ReduceMetrics)
+	// Deferred functions don't run on os.Exit, so the servers and the remote write client are stopped explicitly before exiting
+	closeServers, err := registerServers(reg, cfg)
 	if err != nil {
-		slog.Error("Failed to create save collector", "err", err)
+		slog.Error("Failed to create collectors", "err", err)
 		os.Exit(1)
 	}
-	reg.MustRegister(sc)
-
-	if cfg.RCON.Enable {
-		rc, err := rcon.NewRCONCollector(cfg)
-		if err != nil {
-			slog.Error("Failed to create rcon collector", "err", err)
-			os.Exit(1)
-		}
-		defer rc.Close()
-		reg.MustRegister(rc)
-		err = sc.SetRCONClient(rc.Client())
-		if err != nil {
-			slog.Error("Failed to read the minecraft version from save", "err", err)
-			os.Exit(1)
-		}
-	}
 
+	stopRemoteWrite := func() {}
 	if cfg.Remote.Enable {
 		opts := []promremote.ClientOption{promremote.WithInstanceLabel(cfg.Remote.Instance), promremote.WithJobLabel(cfg.Remote.JobName)}
 		if cfg.Remote.Username != "" {
 			opts = append(opts, promremote.WithBasicAuth(cfg.Remote.Username, cfg.Remote.Password))
 		}
-		rwClient, err := promremote.NewWriteClient(cfg.Remote.URL, reg, opts...)
+		rwReg := prometheus.NewRegistry()
+		rwReg.MustRegister(validMetricsCollector{reg})
+		rwClient, err := promremote.NewWriteClient(cfg.Remote.URL, rwReg, opts...)
 		if err != nil {
 			slog.Error("Failed to create remote write client", "err", err)
+			closeServers()
 			os.Exit(1)
 		}
 
@@ -91,14 +227,17 @@ func main() {
 		err = rwClient.Run(cfg.Interval)
 		if err != nil {
 			slog.Error("Failed to start remote write client", "err", err)
+			closeServers()
 			os.Exit(1)
 		}
-		defer rwClient.Stop()
+		stopRemoteWrite = rwClient.Stop
 	}
 
 	router := http.NewServeMux()
 	router.HandleFunc("/", ServerRootHandler)
-	router.Handle("/metrics", promhttp.HandlerFor(reg, promhttp.HandlerOpts{Registry: reg}))
+	// Serve the remaining metrics when single collectors fail, e.g. a player that can't be loaded
+	router.Handle("/metrics", promhttp.HandlerFor(reg, promhttp.HandlerOpts{Registry: reg, ErrorHandling: promhttp.ContinueOnError}))
+	router.Handle("/probe", probe.NewHandler(cfg.Probe.Modules))
 
 	server := &http.Server{
 		Addr:         ":" + strconv.Itoa(cfg.Port),
@@ -109,6 +248,8 @@ func main() {
 
 	slog.Info("Starting http server", slog.String("addr", server.Addr))
 	err = server.ListenAndServe()
+	stopRemoteWrite()
+	closeServers()
 	if err != nil && !errors.Is(err, http.ErrServerClosed) {
 		slog.Error("Failed to start http server", "err", err)
 		os.Exit(1)
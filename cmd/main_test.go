@@ -1,6 +1,7 @@
 package main
 
 import (
+	"errors"
 	"flag"
 	"net/http"
 	"net/http/httptest"
@@ -8,6 +9,8 @@ import (
 	"os/exec"
 	"testing"
 
+	"github.com/heathcliff26/minecraft-exporter/pkg/config"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -29,6 +32,98 @@ func TestServerRootHandler(t *testing.T) {
 	assert.Contains(body, "<a href='/metrics'>")
 }
 
+func TestRegisterServer(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	cfg := config.DefaultConfig()
+	cfg.Servers = []config.ServerConfig{
+		{Instance: "lobby", ServerType: config.SERVER_TYPE_VANILLA, Worlds: config.WorldsConfig{{Name: "world", Path: "testdata/1.20"}}},
+		{Instance: "survival", ServerType: config.SERVER_TYPE_VANILLA, Worlds: config.WorldsConfig{{Name: "world", Path: "testdata/1.20"}}},
+	}
+
+	reg := prometheus.NewRegistry()
+	cleanup, err := registerServers(reg, cfg)
+	require.NoError(err, "Should register collectors")
+	defer cleanup()
+
+	// Player name lookups need network access, ignore players that fail to load
+	validReg := prometheus.NewRegistry()
+	require.NoError(validReg.Register(validMetricsCollector{reg}), "Should register registry")
+	families, err := validReg.Gather()
+	require.NoError(err, "Should gather metrics")
+	servers := make(map[string]bool, 2)
+	for _, family := range families {
+		for _, metric := range family.GetMetric() {
+			labels := make(map[string]string, len(metric.GetLabel()))
+			for _, label := range metric.GetLabel() {
+				labels[label.GetName()] = label.GetValue()
+			}
+			if !assert.Contains(labels, "server", "Should label all metrics with the server") {
+				continue
+			}
+			if instance, ok := labels["instance"]; ok {
+				assert.Equal(instance, labels["server"], "Should use the instance as server label")
+			}
+			servers[labels["server"]] = true
+		}
+	}
+	assert.Equal(map[string]bool{"lobby": true, "survival": true}, servers, "Should collect metrics of all servers")
+
+	cfg.Servers[1].Worlds[0].Path = "/not/a/valid/world/dir"
+	_, err = registerServers(prometheus.NewRegistry(), cfg)
+	assert.Error(err, "Should fail for invalid world")
+}
+
+func TestRegisterSingleServer(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Instance = "survival"
+	cfg.Worlds = config.WorldsConfig{{Name: "world", Path: "testdata/1.20"}}
+
+	reg := prometheus.NewRegistry()
+	cleanup, err := registerServers(reg, cfg)
+	require.NoError(t, err, "Should register collectors")
+	defer cleanup()
+
+	validReg := prometheus.NewRegistry()
+	require.NoError(t, validReg.Register(validMetricsCollector{reg}), "Should register registry")
+	families, err := validReg.Gather()
+	require.NoError(t, err, "Should gather metrics")
+	for _, family := range families {
+		for _, metric := range family.GetMetric() {
+			labels := make(map[string]string, len(metric.GetLabel()))
+			for _, label := range metric.GetLabel() {
+				labels[label.GetName()] = label.GetValue()
+			}
+			assert.Equal(t, "survival", labels["server"], "Should use the same labels as multiple servers")
+		}
+	}
+}
+
+func TestValidMetricsCollector(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	desc := prometheus.NewDesc("test_metric", "Metric for testing", []string{"label"}, nil)
+	collector := prometheus.CollectorFunc(func(ch chan<- prometheus.Metric) {
+		ch <- prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, 1, "valid")
+		ch <- prometheus.NewInvalidMetric(desc, errors.New("invalid metric"))
+	})
+
+	reg := prometheus.NewRegistry()
+	require.NoError(reg.Register(collector), "Should register collector")
+	_, err := reg.Gather()
+	require.Error(err, "Should fail without filtering invalid metrics")
+
+	validReg := prometheus.NewRegistry()
+	require.NoError(validReg.Register(validMetricsCollector{reg}), "Should register registry")
+	families, err := validReg.Gather()
+	require.NoError(err, "Should drop invalid metrics")
+	require.Len(families, 1)
+	require.Len(families[0].GetMetric(), 1, "Should keep valid metrics")
+	assert.Equal("valid", families[0].GetMetric()[0].GetLabel()[0].GetValue())
+}
+
 func TestShowVersion(t *testing.T) {
 	if os.Getenv("RUN_CRASH_TEST") == "1" {
 		_ = flag.CommandLine.Parse([]string{"-version"})
@@ -3,6 +3,9 @@ package config
 import (
 	"log/slog"
 	"os"
+	"path"
+	"path/filepath"
+	"slices"
 	"strings"
 	"time"
 
@@ -15,7 +18,20 @@ const (
 	DEFAULT_PORT            = 8080
 	DEFAULT_INTERVAL        = 1 * time.Minute
 	DEFAULT_WORLD_DIR       = "/world"
+	DEFAULT_WORLD_NAME      = "world"
 	DEFAULT_REMOTE_JOB_NAME = "minecraft-exporter"
+
+	DEFAULT_PROBE_TIMEOUT = 5 * time.Second
+
+	DEFAULT_CONTAINER_AUDIT_INTERVAL = 1 * time.Hour
+	MIN_CONTAINER_AUDIT_INTERVAL     = 30 * time.Second
+
+	// Size of a region file in chunks
+	DEFAULT_INHABITED_TIME_GRID = 32
+	// Smaller cells would export a series for nearly every visited chunk
+	MIN_INHABITED_TIME_GRID = 4
+
+	DEFAULT_PLAYER_CACHE_SIZE = 1000
 )
 
 const (
@@ -25,6 +41,11 @@ const (
 	SERVER_TYPE_NEOFORGE = "neoforge"
 )
 
+const (
+	PROBER_RCON = "rcon"
+	PROBER_PING = "ping"
+)
+
 var logLevel *slog.LevelVar
 
 // Initialize the logger
@@ -38,16 +59,103 @@ func init() {
 }
 
 type Config struct {
-	LogLevel      string        `yaml:"logLevel,omitempty"`
-	Port          int           `yaml:"port,omitempty"`
-	Interval      time.Duration `yaml:"interval,omitempty"`
-	Instance      string        `yaml:"instance"`
-	ReduceMetrics bool          `yaml:"reduceMetrics,omitempty"`
+	LogLevel      string         `yaml:"logLevel,omitempty"`
+	Port          int            `yaml:"port,omitempty"`
+	Interval      time.Duration  `yaml:"interval,omitempty"`
+	Instance      string         `yaml:"instance"`
+	ReduceMetrics bool           `yaml:"reduceMetrics,omitempty"`
+	ServerType    string         `yaml:"server,omitempty"`
+	DynmapEnabled bool           `yaml:"dynmap,omitempty"`
+	Worlds        WorldsConfig   `yaml:"world,omitempty"`
+	Save          SaveConfig     `yaml:"save,omitempty"`
+	RCON          RCONConfig     `yaml:"rcon,omitempty"`
+	Servers       []ServerConfig `yaml:"servers,omitempty"`
+	Probe         ProbeConfig    `yaml:"probe,omitempty"`
+	Remote        RemoteConfig   `yaml:"remote,omitempty"`
+}
+
+// A single minecraft server. When servers are configured, the top level instance, server, dynmap, world and rcon options are ignored.
+type ServerConfig struct {
+	Instance      string       `yaml:"instance"`
+	ServerType    string       `yaml:"server,omitempty"`
+	DynmapEnabled bool         `yaml:"dynmap,omitempty"`
+	Worlds        WorldsConfig `yaml:"world,omitempty"`
+	RCON          RCONConfig   `yaml:"rcon,omitempty"`
+}
+
+// The worlds of the server. Can be given as a single path or a list of named worlds.
+// The first world is the primary world, player data is only read from it.
+type WorldsConfig []WorldConfig
+
+type WorldConfig struct {
+	Name string `yaml:"name,omitempty"`
+	Path string `yaml:"path"`
+}
+
+// Accept either a single path or a list of worlds
+func (w *WorldsConfig) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		var path string
+		err := value.Decode(&path)
+		if err != nil {
+			return err
+		}
+		*w = WorldsConfig{{Path: path}}
+		return nil
+	}
+
+	var worlds []WorldConfig
+	err := value.Decode(&worlds)
+	if err != nil {
+		return err
+	}
+	*w = worlds
+	return nil
+}
+
+// Return the primary world, which contains the player data
+func (w WorldsConfig) Primary() WorldConfig {
+	return w[0]
+}
+
+type SaveConfig struct {
+	Items              []string             `yaml:"items,omitempty"`
+	StatNamespaces     []string             `yaml:"statNamespaces,omitempty"`
+	AdvancementDetails bool                 `yaml:"advancementDetails,omitempty"`
+	Workers            int                  `yaml:"workers,omitempty"`
+	PlayerCacheSize    int                  `yaml:"playerCacheSize,omitempty"`
+	Scoreboard         ScoreboardConfig     `yaml:"scoreboard,omitempty"`
+	ScanEntities       bool                 `yaml:"scanEntities,omitempty"`
+	TopChunks          int                  `yaml:"topChunks,omitempty"`
+	ScanChunks         bool                 `yaml:"scanChunks,omitempty"`
+	InhabitedTimeGrid  int                  `yaml:"inhabitedTimeGrid,omitempty"`
+	ContainerAudit     ContainerAuditConfig `yaml:"containerAudit,omitempty"`
+}
+
+type ContainerAuditConfig struct {
+	Enable   bool          `yaml:"enable"`
+	Interval time.Duration `yaml:"interval,omitempty"`
+	Items    []string      `yaml:"items,omitempty"`
+}
+
+type ScoreboardConfig struct {
+	Include []string `yaml:"include,omitempty"`
+	Exclude []string `yaml:"exclude,omitempty"`
+}
+
+type ProbeConfig struct {
+	Modules map[string]ProbeModule `yaml:"modules,omitempty"`
+}
+
+// Module for the /probe endpoint, selected with the module parameter
+type ProbeModule struct {
+	Prober        string        `yaml:"prober"`
+	Timeout       time.Duration `yaml:"timeout,omitempty"`
 	ServerType    string        `yaml:"server,omitempty"`
 	DynmapEnabled bool          `yaml:"dynmap,omitempty"`
-	WorldDir      string        `yaml:"world,omitempty"`
-	RCON          RCONConfig    `yaml:"rcon,omitempty"`
-	Remote        RemoteConfig  `yaml:"remote,omitempty"`
+	Password      string        `yaml:"password,omitempty"`
+	// Targets the module is allowed to probe, all other targets are rejected
+	Targets []string `yaml:"targets,omitempty"`
 }
 
 type RCONConfig struct {
@@ -79,11 +187,22 @@ func DefaultConfig() Config {
 		Interval:   DEFAULT_INTERVAL,
 		Instance:   hostname,
 		ServerType: SERVER_TYPE_VANILLA,
-		WorldDir:   DEFAULT_WORLD_DIR,
+		Worlds:     WorldsConfig{{Name: DEFAULT_WORLD_NAME, Path: DEFAULT_WORLD_DIR}},
+		Save:       defaultSaveConfig(),
 		Remote:     defaultRemoteConfig(),
 	}
 }
 
+func defaultSaveConfig() SaveConfig {
+	return SaveConfig{
+		PlayerCacheSize:   DEFAULT_PLAYER_CACHE_SIZE,
+		InhabitedTimeGrid: DEFAULT_INHABITED_TIME_GRID,
+		ContainerAudit: ContainerAuditConfig{
+			Interval: DEFAULT_CONTAINER_AUDIT_INTERVAL,
+		},
+	}
+}
+
 func defaultRemoteConfig() RemoteConfig {
 	return RemoteConfig{
 		JobName: DEFAULT_REMOTE_JOB_NAME,
@@ -124,8 +243,77 @@ func LoadConfig(path string, env bool) (Config, error) {
 	if err != nil {
 		return Config{}, err
 	}
-	if c.ServerType != SERVER_TYPE_VANILLA && c.ServerType != SERVER_TYPE_FORGE && c.ServerType != SERVER_TYPE_PAPER && c.ServerType != SERVER_TYPE_NEOFORGE {
-		return Config{}, &ErrUnknownServerType{Type: c.ServerType}
+	if len(c.Servers) == 0 {
+		err = validateServerType(c.ServerType)
+		if err != nil {
+			return Config{}, err
+		}
+		err = validateWorlds(c.Worlds)
+		if err != nil {
+			return Config{}, err
+		}
+	}
+	instances := make(map[string]bool, len(c.Servers))
+	for i := range c.Servers {
+		server := &c.Servers[i]
+		if server.Instance == "" {
+			return Config{}, &ErrMissingInstance{}
+		}
+		if instances[server.Instance] {
+			return Config{}, &ErrDuplicateInstance{Instance: server.Instance}
+		}
+		instances[server.Instance] = true
+
+		if server.ServerType == "" {
+			server.ServerType = SERVER_TYPE_VANILLA
+		}
+		err = validateServerType(server.ServerType)
+		if err != nil {
+			return Config{}, err
+		}
+		err = validateWorlds(server.Worlds)
+		if err != nil {
+			return Config{}, err
+		}
+	}
+
+	for name, module := range c.Probe.Modules {
+		if module.Prober != PROBER_RCON && module.Prober != PROBER_PING {
+			return Config{}, &ErrUnknownProber{Module: name, Prober: module.Prober}
+		}
+		if len(module.Targets) == 0 {
+			return Config{}, &ErrMissingProbeTargets{Module: name}
+		}
+		if module.Timeout == 0 {
+			module.Timeout = DEFAULT_PROBE_TIMEOUT
+		}
+		if module.ServerType == "" {
+			module.ServerType = SERVER_TYPE_VANILLA
+		}
+		err = validateServerType(module.ServerType)
+		if err != nil {
+			return Config{}, err
+		}
+		c.Probe.Modules[name] = module
+	}
+
+	err = validatePatterns(slices.Concat(c.Save.Scoreboard.Include, c.Save.Scoreboard.Exclude))
+	if err != nil {
+		return Config{}, err
+	}
+
+	// The chunks with the most entities are found by the entity census
+	if c.Save.TopChunks > 0 && !c.Save.ScanEntities {
+		return Config{}, &ErrTopChunksWithoutEntities{}
+	}
+
+	// 0 disables the inhabited time metric
+	if c.Save.InhabitedTimeGrid != 0 && c.Save.InhabitedTimeGrid < MIN_INHABITED_TIME_GRID {
+		return Config{}, &ErrInvalidInhabitedTimeGrid{Grid: c.Save.InhabitedTimeGrid}
+	}
+
+	if c.Save.ContainerAudit.Enable && c.Save.ContainerAudit.Interval < MIN_CONTAINER_AUDIT_INTERVAL {
+		return Config{}, NewErrInvalidInterval("save.containerAudit.interval", c.Save.ContainerAudit.Interval, MIN_CONTAINER_AUDIT_INTERVAL)
 	}
 
 	if c.Remote.Instance == "" {
@@ -144,6 +332,60 @@ func LoadConfig(path string, env bool) (Config, error) {
 	return c, nil
 }
 
+// Return the servers to collect metrics from.
+// Without a list of servers, the top level options describe a single server.
+func (c Config) GetServers() []ServerConfig {
+	if len(c.Servers) > 0 {
+		return c.Servers
+	}
+	return []ServerConfig{
+		{
+			Instance:      c.Instance,
+			ServerType:    c.ServerType,
+			DynmapEnabled: c.DynmapEnabled,
+			Worlds:        c.Worlds,
+			RCON:          c.RCON,
+		},
+	}
+}
+
+func validateServerType(serverType string) error {
+	if serverType != SERVER_TYPE_VANILLA && serverType != SERVER_TYPE_FORGE && serverType != SERVER_TYPE_PAPER && serverType != SERVER_TYPE_NEOFORGE {
+		return &ErrUnknownServerType{Type: serverType}
+	}
+	return nil
+}
+
+// Check that the glob patterns are valid, as malformed patterns would silently never match
+func validatePatterns(patterns []string) error {
+	for _, pattern := range patterns {
+		_, err := path.Match(pattern, "")
+		if err != nil {
+			return &ErrInvalidPattern{Pattern: pattern, Err: err}
+		}
+	}
+	return nil
+}
+
+// Ensure there is at least one world and fill in the default names.
+// The names need to be unique, as they are used to label the metrics.
+func validateWorlds(worlds WorldsConfig) error {
+	if len(worlds) == 0 {
+		return &ErrNoWorld{}
+	}
+	names := make(map[string]bool, len(worlds))
+	for i := range worlds {
+		if worlds[i].Name == "" {
+			worlds[i].Name = filepath.Base(worlds[i].Path)
+		}
+		if names[worlds[i].Name] {
+			return &ErrDuplicateWorldName{Name: worlds[i].Name}
+		}
+		names[worlds[i].Name] = true
+	}
+	return nil
+}
+
 // Parse a given string and set the resulting log level
 func setLogLevel(level string) error {
 	switch strings.ToLower(level) {
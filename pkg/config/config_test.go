@@ -2,6 +2,7 @@ package config
 
 import (
 	"log/slog"
+	"path"
 	"reflect"
 	"strconv"
 	"testing"
@@ -18,13 +19,50 @@ func TestValidConfigs(t *testing.T) {
 		Interval:   5 * time.Minute,
 		Instance:   "testinstance",
 		ServerType: SERVER_TYPE_VANILLA,
-		WorldDir:   "/path/to/world",
+		Worlds:     WorldsConfig{{Name: "world", Path: "/path/to/world"}},
+		Save: SaveConfig{
+			Items:              []string{"diamond", "minecraft:elytra"},
+			StatNamespaces:     []string{"create"},
+			AdvancementDetails: true,
+			Workers:            4,
+			PlayerCacheSize:    500,
+			Scoreboard: ScoreboardConfig{
+				Include: []string{"eco_*"},
+				Exclude: []string{"eco_debug"},
+			},
+			ScanEntities:      true,
+			TopChunks:         5,
+			ScanChunks:        true,
+			InhabitedTimeGrid: 16,
+			ContainerAudit: ContainerAuditConfig{
+				Enable:   true,
+				Interval: 30 * time.Minute,
+				Items:    []string{"diamond"},
+			},
+		},
 		RCON: RCONConfig{
 			Enable:   true,
 			Host:     "localhost",
 			Port:     25575,
 			Password: "password",
 		},
+		Probe: ProbeConfig{
+			Modules: map[string]ProbeModule{
+				"rcon_paper": {
+					Prober:     PROBER_RCON,
+					Timeout:    DEFAULT_PROBE_TIMEOUT,
+					ServerType: SERVER_TYPE_PAPER,
+					Password:   "password",
+					Targets:    []string{"paper.example.org:25575"},
+				},
+				"ping": {
+					Prober:     PROBER_PING,
+					Timeout:    2 * time.Second,
+					ServerType: SERVER_TYPE_VANILLA,
+					Targets:    []string{"mc1.example.org:25565", "mc2.example.org:25565"},
+				},
+			},
+		},
 		Remote: defaultRemoteConfig(),
 	}
 	c1.Remote.Instance = "testinstance"
@@ -33,8 +71,13 @@ func TestValidConfigs(t *testing.T) {
 		Port:       2080,
 		Interval:   30 * time.Minute,
 		Instance:   "another-instance",
-		ServerType: SERVER_TYPE_VANILLA,
-		WorldDir:   DEFAULT_WORLD_DIR,
+		ServerType: SERVER_TYPE_PAPER,
+		Worlds: WorldsConfig{
+			{Name: "world", Path: "/server/world"},
+			{Name: "world_nether", Path: "/server/world_nether"},
+			{Name: "end", Path: "/server/world_the_end"},
+		},
+		Save: defaultSaveConfig(),
 		Remote: RemoteConfig{
 			Enable:   true,
 			URL:      "https://example.org/",
@@ -50,7 +93,8 @@ func TestValidConfigs(t *testing.T) {
 		Interval:   DEFAULT_INTERVAL,
 		Instance:   "test",
 		ServerType: SERVER_TYPE_VANILLA,
-		WorldDir:   DEFAULT_WORLD_DIR,
+		Worlds:     WorldsConfig{{Name: DEFAULT_WORLD_NAME, Path: DEFAULT_WORLD_DIR}},
+		Save:       defaultSaveConfig(),
 		Remote: RemoteConfig{
 			Enable:   true,
 			URL:      "https://example.org/",
@@ -58,6 +102,31 @@ func TestValidConfigs(t *testing.T) {
 			JobName:  "testjob",
 		},
 	}
+	c4 := DefaultConfig()
+	c4.LogLevel = "info"
+	c4.Servers = []ServerConfig{
+		{
+			Instance:   "lobby",
+			ServerType: SERVER_TYPE_VANILLA,
+			Worlds:     WorldsConfig{{Name: "world", Path: "/servers/lobby/world"}},
+		},
+		{
+			Instance:      "survival",
+			ServerType:    SERVER_TYPE_PAPER,
+			DynmapEnabled: true,
+			Worlds: WorldsConfig{
+				{Name: "world", Path: "/servers/survival/world"},
+				{Name: "world_nether", Path: "/servers/survival/world_nether"},
+			},
+			RCON: RCONConfig{
+				Enable:   true,
+				Host:     "survival",
+				Port:     25575,
+				Password: "password",
+			},
+		},
+	}
+	c4.Remote.Instance = c4.Instance
 	tMatrix := []struct {
 		Name, Path string
 		Result     Config
@@ -82,6 +151,11 @@ func TestValidConfigs(t *testing.T) {
 			Path:   "testdata/valid-config-3.yaml",
 			Result: c3,
 		},
+		{
+			Name:   "Config4",
+			Path:   "testdata/valid-config-4.yaml",
+			Result: c4,
+		},
 	}
 
 	for _, tCase := range tMatrix {
@@ -125,6 +199,56 @@ func TestInvalidConfig(t *testing.T) {
 			Path:  "testdata/invalid-config-3.yaml",
 			Error: "promremote.ErrMissingAuthCredentials",
 		},
+		{
+			Name:  "ContainerAuditIntervalTooShort",
+			Path:  "testdata/invalid-config-4.yaml",
+			Error: "*config.ErrInvalidInterval",
+		},
+		{
+			Name:  "DuplicateWorldName",
+			Path:  "testdata/invalid-config-5.yaml",
+			Error: "*config.ErrDuplicateWorldName",
+		},
+		{
+			Name:  "NoWorld",
+			Path:  "testdata/invalid-config-6.yaml",
+			Error: "*config.ErrNoWorld",
+		},
+		{
+			Name:  "DuplicateInstance",
+			Path:  "testdata/invalid-config-7.yaml",
+			Error: "*config.ErrDuplicateInstance",
+		},
+		{
+			Name:  "MissingServerInstance",
+			Path:  "testdata/invalid-config-8.yaml",
+			Error: "*config.ErrMissingInstance",
+		},
+		{
+			Name:  "UnknownProber",
+			Path:  "testdata/invalid-config-9.yaml",
+			Error: "*config.ErrUnknownProber",
+		},
+		{
+			Name:  "InvalidInhabitedTimeGrid",
+			Path:  "testdata/invalid-config-10.yaml",
+			Error: "*config.ErrInvalidInhabitedTimeGrid",
+		},
+		{
+			Name:  "InvalidScoreboardPattern",
+			Path:  "testdata/invalid-config-11.yaml",
+			Error: "*config.ErrInvalidPattern",
+		},
+		{
+			Name:  "MissingProbeTargets",
+			Path:  "testdata/invalid-config-12.yaml",
+			Error: "*config.ErrMissingProbeTargets",
+		},
+		{
+			Name:  "TopChunksWithoutEntities",
+			Path:  "testdata/invalid-config-13.yaml",
+			Error: "*config.ErrTopChunksWithoutEntities",
+		},
 	}
 
 	for _, tCase := range tMatrix {
@@ -147,13 +271,14 @@ func TestEnvSubstitution(t *testing.T) {
 		Port:       2080,
 		Interval:   time.Minute,
 		ServerType: SERVER_TYPE_VANILLA,
-		WorldDir:   "/some/server/world",
+		Worlds:     WorldsConfig{{Name: "world", Path: "/some/server/world"}},
+		Save:       defaultSaveConfig(),
 		Remote:     defaultRemoteConfig(),
 	}
 	t.Setenv("MINECRAFT_EXPORTER_LOG_LEVEL", c.LogLevel)
 	t.Setenv("MINECRAFT_EXPORTER_PORT", strconv.Itoa(c.Port))
 	t.Setenv("MINECRAFT_EXPORTER_CACHE", c.Interval.String())
-	t.Setenv("MINECRAFT_EXPORTER_WORLD_DIR", c.WorldDir)
+	t.Setenv("MINECRAFT_EXPORTER_WORLD_DIR", c.Worlds.Primary().Path)
 
 	res, err := LoadConfig("testdata/env-config.yaml", true)
 
@@ -168,6 +293,33 @@ func TestEnvSubstitution(t *testing.T) {
 	assert.Equal(c, res)
 }
 
+func TestGetServers(t *testing.T) {
+	assert := assert.New(t)
+
+	c := DefaultConfig()
+	c.DynmapEnabled = true
+	c.RCON.Enable = true
+	servers := c.GetServers()
+	assert.Equal([]ServerConfig{{
+		Instance:      c.Instance,
+		ServerType:    SERVER_TYPE_VANILLA,
+		DynmapEnabled: true,
+		Worlds:        c.Worlds,
+		RCON:          c.RCON,
+	}}, servers, "Should use the top level options without servers")
+
+	c.Servers = []ServerConfig{{Instance: "a"}, {Instance: "b"}}
+	assert.Equal(c.Servers, c.GetServers(), "Should return the configured servers")
+}
+
+func TestValidatePatterns(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.NoError(validatePatterns([]string{"eco_*", "kills", "[a-z]?"}), "Should accept valid patterns")
+	err := validatePatterns([]string{"eco_*", "eco_[debug"})
+	assert.ErrorIs(err, path.ErrBadPattern, "Should reject malformed patterns")
+}
+
 func TestSetLogLevel(t *testing.T) {
 	tMatrix := []struct {
 		Name  string
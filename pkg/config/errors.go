@@ -1,6 +1,9 @@
 package config
 
-import "time"
+import (
+	"strconv"
+	"time"
+)
 
 type ErrUnknownLogLevel struct {
 	Level string
@@ -11,11 +14,42 @@ func (e *ErrUnknownLogLevel) Error() string {
 }
 
 type ErrInvalidInterval struct {
+	Field    string
 	Interval time.Duration
+	Min      time.Duration
+}
+
+func NewErrInvalidInterval(field string, interval, minimum time.Duration) *ErrInvalidInterval {
+	return &ErrInvalidInterval{
+		Field:    field,
+		Interval: interval,
+		Min:      minimum,
+	}
 }
 
 func (e *ErrInvalidInterval) Error() string {
-	return "Interval is to short, needs to be at least 30s, current " + e.Interval.String()
+	return "Interval " + e.Field + " is too short, needs to be at least " + e.Min.String() + ", current " + e.Interval.String()
+}
+
+type ErrInvalidInhabitedTimeGrid struct {
+	Grid int
+}
+
+func (e *ErrInvalidInhabitedTimeGrid) Error() string {
+	return "Inhabited time grid needs to be 0 to disable it or at least " + strconv.Itoa(MIN_INHABITED_TIME_GRID) + ", current " + strconv.Itoa(e.Grid)
+}
+
+type ErrInvalidPattern struct {
+	Pattern string
+	Err     error
+}
+
+func (e *ErrInvalidPattern) Error() string {
+	return "Invalid glob pattern \"" + e.Pattern + "\": " + e.Err.Error()
+}
+
+func (e *ErrInvalidPattern) Unwrap() error {
+	return e.Err
 }
 
 type ErrUnknownServerType struct {
@@ -25,3 +59,53 @@ type ErrUnknownServerType struct {
 func (e *ErrUnknownServerType) Error() string {
 	return "Received unknown server type " + e.Type
 }
+
+type ErrNoWorld struct{}
+
+func (e *ErrNoWorld) Error() string {
+	return "Need at least one world"
+}
+
+type ErrDuplicateWorldName struct {
+	Name string
+}
+
+func (e *ErrDuplicateWorldName) Error() string {
+	return "World name is used more than once: " + e.Name
+}
+
+type ErrMissingInstance struct{}
+
+func (e *ErrMissingInstance) Error() string {
+	return "Every server needs an instance"
+}
+
+type ErrDuplicateInstance struct {
+	Instance string
+}
+
+func (e *ErrDuplicateInstance) Error() string {
+	return "Instance is used by more than one server: " + e.Instance
+}
+
+type ErrUnknownProber struct {
+	Module, Prober string
+}
+
+func (e *ErrUnknownProber) Error() string {
+	return "Module " + e.Module + " uses unknown prober " + e.Prober
+}
+
+type ErrMissingProbeTargets struct {
+	Module string
+}
+
+func (e *ErrMissingProbeTargets) Error() string {
+	return "Module " + e.Module + " needs at least one target"
+}
+
+type ErrTopChunksWithoutEntities struct{}
+
+func (e *ErrTopChunksWithoutEntities) Error() string {
+	return "save.topChunks requires save.scanEntities to be enabled"
+}
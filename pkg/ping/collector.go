@@ -0,0 +1,46 @@
+package ping
+
+import (
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Exports the status of a minecraft server, as returned by a server list ping
+type StatusCollector struct {
+	status   Status
+	Instance string
+}
+
+var (
+	commonVariableLabels = []string{"instance"}
+
+	statusPlayersOnlineDesc = prometheus.NewDesc("minecraft_status_players_online", "Number of players online, as reported by the server list ping", commonVariableLabels, nil)
+	statusPlayersMaxDesc    = prometheus.NewDesc("minecraft_status_players_max", "Maximum number of players, as reported by the server list ping", commonVariableLabels, nil)
+	statusLatencyDesc       = prometheus.NewDesc("minecraft_status_latency_seconds", "Round trip time of the server list ping", commonVariableLabels, nil)
+	statusVersionDesc       = prometheus.NewDesc("minecraft_status_version_info", "Version and protocol the server reports. Value is always 1", append(commonVariableLabels, "version", "protocol"), nil)
+)
+
+// Create a new collector for the given status
+func NewStatusCollector(status Status, instance string) *StatusCollector {
+	return &StatusCollector{
+		status:   status,
+		Instance: instance,
+	}
+}
+
+// Implements the Describe function for prometheus.Collector
+func (c *StatusCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- statusPlayersOnlineDesc
+	ch <- statusPlayersMaxDesc
+	ch <- statusLatencyDesc
+	ch <- statusVersionDesc
+}
+
+// Implements the Collect function for prometheus.Collector
+func (c *StatusCollector) Collect(ch chan<- prometheus.Metric) {
+	ch <- prometheus.MustNewConstMetric(statusPlayersOnlineDesc, prometheus.GaugeValue, float64(c.status.Players.Online), c.Instance)
+	ch <- prometheus.MustNewConstMetric(statusPlayersMaxDesc, prometheus.GaugeValue, float64(c.status.Players.Max), c.Instance)
+	ch <- prometheus.MustNewConstMetric(statusLatencyDesc, prometheus.GaugeValue, c.status.Latency.Seconds(), c.Instance)
+	ch <- prometheus.MustNewConstMetric(statusVersionDesc, prometheus.GaugeValue, 1, c.Instance, c.status.Version.Name, strconv.Itoa(c.status.Version.Protocol))
+}
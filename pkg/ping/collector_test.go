@@ -0,0 +1,39 @@
+package ping
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatusCollector(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	var status Status
+	status.Version.Name = "1.20.1"
+	status.Version.Protocol = 763
+	status.Players.Online = 3
+	status.Latency = 20 * time.Millisecond
+
+	reg := prometheus.NewPedanticRegistry()
+	require.NoError(reg.Register(NewStatusCollector(status, "localhost:25565")), "Should register collector")
+
+	families, err := reg.Gather()
+	require.NoError(err, "Collected metrics should match the description")
+
+	values := make(map[string]float64, len(families))
+	for _, family := range families {
+		require.Len(family.GetMetric(), 1)
+		values[family.GetName()] = family.GetMetric()[0].GetGauge().GetValue()
+	}
+	assert.Equal(map[string]float64{
+		"minecraft_status_players_online":  3,
+		"minecraft_status_players_max":     0,
+		"minecraft_status_latency_seconds": 0.02,
+		"minecraft_status_version_info":    1,
+	}, values)
+}
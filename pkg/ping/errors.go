@@ -0,0 +1,11 @@
+package ping
+
+import "fmt"
+
+type ErrUnexpectedPacket struct {
+	Expected, Received int32
+}
+
+func (e *ErrUnexpectedPacket) Error() string {
+	return fmt.Sprintf("Expected packet with id %#02x, received %#02x", e.Expected, e.Received)
+}
@@ -0,0 +1,111 @@
+package ping
+
+import (
+	"encoding/json/v2"
+	gonet "net"
+	"strconv"
+	"time"
+
+	"github.com/Tnze/go-mc/net"
+	pk "github.com/Tnze/go-mc/net/packet"
+)
+
+const (
+	PACKET_ID_HANDSHAKE = 0x00
+	PACKET_ID_STATUS    = 0x00
+	PACKET_ID_PING      = 0x01
+
+	// Protocol version send in the handshake, -1 is used by convention when pinging
+	PING_PROTOCOL_VERSION = -1
+	// Next state of the handshake that requests the status
+	HANDSHAKE_NEXT_STATE_STATUS = 1
+)
+
+// Response to a server list ping
+type Status struct {
+	Version struct {
+		Name     string `json:"name"`
+		Protocol int    `json:"protocol"`
+	} `json:"version"`
+	Players struct {
+		Max    int `json:"max"`
+		Online int `json:"online"`
+	} `json:"players"`
+
+	// Round trip time of the ping following the status request
+	Latency time.Duration `json:"-"`
+}
+
+// Send a server list ping to the minecraft server and return the status.
+// The address needs to contain the port.
+func Ping(addr string, timeout time.Duration) (Status, error) {
+	host, portStr, err := gonet.SplitHostPort(addr)
+	if err != nil {
+		return Status{}, err
+	}
+	port, err := strconv.ParseUint(portStr, 10, 16)
+	if err != nil {
+		return Status{}, err
+	}
+
+	conn, err := net.DialMCTimeout(addr, timeout)
+	if err != nil {
+		return Status{}, err
+	}
+	defer conn.Close()
+
+	err = conn.Socket.SetDeadline(time.Now().Add(timeout))
+	if err != nil {
+		return Status{}, err
+	}
+
+	err = conn.WritePacket(pk.Marshal(PACKET_ID_HANDSHAKE,
+		pk.VarInt(PING_PROTOCOL_VERSION),
+		pk.String(host),
+		pk.UnsignedShort(port),
+		pk.VarInt(HANDSHAKE_NEXT_STATE_STATUS),
+	))
+	if err != nil {
+		return Status{}, err
+	}
+	err = conn.WritePacket(pk.Marshal(PACKET_ID_STATUS))
+	if err != nil {
+		return Status{}, err
+	}
+
+	var p pk.Packet
+	err = conn.ReadPacket(&p)
+	if err != nil {
+		return Status{}, err
+	}
+	if p.ID != PACKET_ID_STATUS {
+		return Status{}, &ErrUnexpectedPacket{Expected: PACKET_ID_STATUS, Received: p.ID}
+	}
+	var res pk.String
+	err = p.Scan(&res)
+	if err != nil {
+		return Status{}, err
+	}
+
+	var status Status
+	err = json.Unmarshal([]byte(res), &status)
+	if err != nil {
+		return Status{}, err
+	}
+
+	start := time.Now()
+	err = conn.WritePacket(pk.Marshal(PACKET_ID_PING, pk.Long(start.UnixMilli())))
+	if err != nil {
+		return Status{}, err
+	}
+	err = conn.ReadPacket(&p)
+	if err != nil {
+		return Status{}, err
+	}
+	if p.ID != PACKET_ID_PING {
+		return Status{}, &ErrUnexpectedPacket{Expected: PACKET_ID_PING, Received: p.ID}
+	}
+	status.Latency = time.Since(start)
+
+	return status, nil
+}
@@ -0,0 +1,87 @@
+package ping
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Tnze/go-mc/net"
+	pk "github.com/Tnze/go-mc/net/packet"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testStatus = `{"version":{"name":"1.20.1","protocol":763},"players":{"max":20,"online":3},"description":{"text":"A Minecraft Server"}}`
+
+// Start a server that answers a single server list ping with the given status
+func newTestServer(t *testing.T, status string) string {
+	t.Helper()
+
+	l, err := net.ListenMC("localhost:0")
+	require.NoError(t, err, "Should create server")
+	t.Cleanup(func() { _ = l.Close() })
+
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		var p pk.Packet
+		var protocol, nextState pk.VarInt
+		var host pk.String
+		var port pk.UnsignedShort
+		if conn.ReadPacket(&p) != nil || p.Scan(&protocol, &host, &port, &nextState) != nil || nextState != HANDSHAKE_NEXT_STATE_STATUS {
+			return
+		}
+		if conn.ReadPacket(&p) != nil || p.ID != PACKET_ID_STATUS {
+			return
+		}
+		if conn.WritePacket(pk.Marshal(PACKET_ID_STATUS, pk.String(status))) != nil {
+			return
+		}
+
+		var payload pk.Long
+		if conn.ReadPacket(&p) != nil || p.Scan(&payload) != nil {
+			return
+		}
+		_ = conn.WritePacket(pk.Marshal(PACKET_ID_PING, payload))
+	}()
+
+	return l.Addr().String()
+}
+
+func TestPing(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		assert := assert.New(t)
+
+		addr := newTestServer(t, testStatus)
+
+		status, err := Ping(addr, time.Second)
+		require.NoError(t, err, "Should ping server")
+		assert.Equal("1.20.1", status.Version.Name)
+		assert.Equal(763, status.Version.Protocol)
+		assert.Equal(20, status.Players.Max)
+		assert.Equal(3, status.Players.Online)
+		assert.Greater(status.Latency, time.Duration(0), "Should measure latency")
+	})
+	t.Run("InvalidStatus", func(t *testing.T) {
+		addr := newTestServer(t, "not-json")
+
+		_, err := Ping(addr, time.Second)
+		assert.Error(t, err, "Should fail to decode status")
+	})
+	t.Run("MissingPort", func(t *testing.T) {
+		_, err := Ping("localhost", time.Second)
+		assert.Error(t, err, "Should require a port")
+	})
+	t.Run("ConnectionRefused", func(t *testing.T) {
+		l, err := net.ListenMC("localhost:0")
+		require.NoError(t, err)
+		addr := l.Addr().String()
+		_ = l.Close()
+
+		_, err = Ping(addr, time.Second)
+		assert.Error(t, err, "Should fail to connect")
+	})
+}
@@ -0,0 +1,122 @@
+package probe
+
+import (
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/heathcliff26/minecraft-exporter/pkg/config"
+	"github.com/heathcliff26/minecraft-exporter/pkg/ping"
+	"github.com/heathcliff26/minecraft-exporter/pkg/rcon"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Serves the metrics of a single target, selected with the target and module parameters.
+// Works like the /probe endpoint of the blackbox_exporter.
+type Handler struct {
+	modules map[string]config.ProbeModule
+}
+
+// Create a new handler for the given modules
+func NewHandler(modules map[string]config.ProbeModule) *Handler {
+	return &Handler{
+		modules: modules,
+	}
+}
+
+// Implements http.Handler
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	target := r.URL.Query().Get("target")
+	if target == "" {
+		http.Error(w, "Target parameter is missing", http.StatusBadRequest)
+		return
+	}
+	host, portStr, err := net.SplitHostPort(target)
+	if err != nil {
+		http.Error(w, "Target needs to be in the format host:port", http.StatusBadRequest)
+		return
+	}
+	port, err := strconv.Atoi(portStr)
+	if err != nil {
+		http.Error(w, "Target contains an invalid port", http.StatusBadRequest)
+		return
+	}
+
+	moduleName := r.URL.Query().Get("module")
+	module, ok := h.modules[moduleName]
+	if !ok {
+		http.Error(w, "Unknown module \""+moduleName+"\"", http.StatusBadRequest)
+		return
+	}
+	if !slices.Contains(module.Targets, target) {
+		http.Error(w, "Target is not allowed for module \""+moduleName+"\"", http.StatusForbidden)
+		return
+	}
+
+	probeSuccess := prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "probe_success",
+		Help: "Indicates if the probe was successful",
+	})
+	probeDuration := prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "probe_duration_seconds",
+		Help: "Time it took to connect to the target",
+	})
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(probeSuccess, probeDuration)
+
+	start := time.Now()
+	collector, err := probe(target, host, port, module)
+	probeDuration.Set(time.Since(start).Seconds())
+	if err != nil {
+		slog.Error("Probe failed", slog.String("target", target), slog.String("module", moduleName), "err", err)
+	} else {
+		probeSuccess.Set(1)
+		reg.MustRegister(collector)
+		if closer, ok := collector.(io.Closer); ok {
+			defer closer.Close()
+		}
+	}
+
+	promhttp.HandlerFor(reg, promhttp.HandlerOpts{}).ServeHTTP(w, r)
+}
+
+// Connect to the target and return a collector for its metrics
+func probe(target, host string, port int, module config.ProbeModule) (prometheus.Collector, error) {
+	switch module.Prober {
+	case config.PROBER_PING:
+		status, err := ping.Ping(target, module.Timeout)
+		if err != nil {
+			return nil, err
+		}
+		return ping.NewStatusCollector(status, target), nil
+	case config.PROBER_RCON:
+		c, err := rcon.NewRCONCollector(config.ServerConfig{
+			Instance:      target,
+			ServerType:    module.ServerType,
+			DynmapEnabled: module.DynmapEnabled,
+			RCON: config.RCONConfig{
+				Enable:   true,
+				Host:     host,
+				Port:     port,
+				Password: module.Password,
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+		c.Client().SetDialTimeout(module.Timeout)
+		err = c.Client().Connect()
+		if err != nil {
+			_ = c.Close()
+			return nil, err
+		}
+		return c, nil
+	default:
+		return nil, &config.ErrUnknownProber{Prober: module.Prober}
+	}
+}
@@ -0,0 +1,167 @@
+package probe
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Tnze/go-mc/net"
+	"github.com/heathcliff26/minecraft-exporter/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testRCONPassword = "testpassword"
+
+// Create the modules used in the tests, allowing the given targets
+func newTestModules(targets ...string) map[string]config.ProbeModule {
+	return map[string]config.ProbeModule{
+		"rcon": {
+			Prober:     config.PROBER_RCON,
+			Timeout:    config.DEFAULT_PROBE_TIMEOUT,
+			ServerType: config.SERVER_TYPE_VANILLA,
+			Password:   testRCONPassword,
+			Targets:    targets,
+		},
+		"ping": {
+			Prober:     config.PROBER_PING,
+			Timeout:    config.DEFAULT_PROBE_TIMEOUT,
+			ServerType: config.SERVER_TYPE_VANILLA,
+			Targets:    targets,
+		},
+	}
+}
+
+// Start a RCON server that answers every command with the list of online players
+func newTestRCONServer(t *testing.T) string {
+	t.Helper()
+
+	s, err := net.ListenRCON("localhost:0")
+	require.NoError(t, err, "Should create RCON server")
+	t.Cleanup(func() { _ = s.Close() })
+
+	go func() {
+		conn, err := s.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		if conn.AcceptLogin(testRCONPassword) != nil {
+			return
+		}
+		for {
+			_, err := conn.AcceptCmd()
+			if err != nil {
+				return
+			}
+			if conn.RespCmd("There are 1/10 players online:TestPlayer") != nil {
+				return
+			}
+		}
+	}()
+
+	return s.Addr().String()
+}
+
+func probeTarget(modules map[string]config.ProbeModule, query string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/probe?"+query, nil)
+	rr := httptest.NewRecorder()
+	NewHandler(modules).ServeHTTP(rr, req)
+	return rr
+}
+
+func TestProbeInvalidRequest(t *testing.T) {
+	tMatrix := map[string]string{
+		"MissingTarget": "module=rcon",
+		"MissingPort":   "target=localhost&module=rcon",
+		"InvalidPort":   "target=localhost:port&module=rcon",
+		"UnknownModule": "target=localhost:25575&module=query",
+	}
+
+	for name, query := range tMatrix {
+		t.Run(name, func(t *testing.T) {
+			rr := probeTarget(newTestModules("localhost:25575"), query)
+			assert.Equal(t, http.StatusBadRequest, rr.Code, "Should reject request")
+		})
+	}
+}
+
+func TestProbeRCON(t *testing.T) {
+	assert := assert.New(t)
+
+	addr := newTestRCONServer(t)
+
+	rr := probeTarget(newTestModules(addr), "target="+addr+"&module=rcon")
+	assert.Equal(http.StatusOK, rr.Code)
+	body := rr.Body.String()
+	assert.Contains(body, "probe_success 1", "Should connect to target")
+	assert.Contains(body, "probe_duration_seconds", "Should report the duration")
+	assert.Contains(body, `minecraft_player_online{instance="`+addr+`",player="TestPlayer"} 1`, "Should collect metrics of the target")
+}
+
+func TestProbeTargetNotAllowed(t *testing.T) {
+	addr := newTestRCONServer(t)
+
+	tMatrix := map[string][]string{
+		"OtherTarget": {"mc.example.org:25575"},
+		"NoTargets":   nil,
+	}
+
+	for name, targets := range tMatrix {
+		t.Run(name, func(t *testing.T) {
+			rr := probeTarget(newTestModules(targets...), "target="+addr+"&module=rcon")
+			assert.Equal(t, http.StatusForbidden, rr.Code, "Should reject target that is not allowed")
+		})
+	}
+}
+
+func TestProbeRCONTimeout(t *testing.T) {
+	assert := assert.New(t)
+
+	s, err := net.ListenRCON("localhost:0")
+	require.NoError(t, err, "Should create RCON server")
+	t.Cleanup(func() { _ = s.Close() })
+	go func() {
+		// Accept the connection, but never answer the login
+		conn, err := s.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		time.Sleep(3 * time.Second)
+	}()
+
+	modules := map[string]config.ProbeModule{
+		"rcon": {
+			Prober:     config.PROBER_RCON,
+			Timeout:    100 * time.Millisecond,
+			ServerType: config.SERVER_TYPE_VANILLA,
+			Password:   testRCONPassword,
+			Targets:    []string{s.Addr().String()},
+		},
+	}
+	req := httptest.NewRequest(http.MethodGet, "/probe?target="+s.Addr().String()+"&module=rcon", nil)
+	rr := httptest.NewRecorder()
+
+	start := time.Now()
+	NewHandler(modules).ServeHTTP(rr, req)
+	assert.Less(time.Since(start), time.Second, "Should respect the timeout of the module")
+	assert.Contains(rr.Body.String(), "probe_success 0", "Should report failed probe")
+}
+
+func TestProbeFailure(t *testing.T) {
+	l, err := net.ListenMC("localhost:0")
+	require.NoError(t, err)
+	addr := l.Addr().String()
+	_ = l.Close()
+
+	for _, module := range []string{"rcon", "ping"} {
+		t.Run(module, func(t *testing.T) {
+			rr := probeTarget(newTestModules(addr), "target="+addr+"&module="+module)
+			assert.Equal(t, http.StatusOK, rr.Code, "Should still return metrics")
+			assert.Contains(t, rr.Body.String(), "probe_success 0", "Should report failed probe")
+		})
+	}
+}
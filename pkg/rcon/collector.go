@@ -44,8 +44,8 @@ var (
 // Create new instance of collector, returns error if RCON is not correctly configured not provided
 // Arguments:
 //
-//	cfg: Configuration for minecraft-exporter. Needs RCON to be filled out in full
-func NewRCONCollector(cfg config.Config) (*RCONCollector, error) {
+//	cfg: Configuration of the minecraft server. Needs RCON to be filled out in full
+func NewRCONCollector(cfg config.ServerConfig) (*RCONCollector, error) {
 	rc, err := NewRCONClient(cfg.RCON.Host, cfg.RCON.Port, cfg.RCON.Password)
 	if err != nil {
 		return nil, err
@@ -21,7 +21,7 @@ func TestRCONCollectorDescribe(t *testing.T) {
 	s, port := newTestServer(t)
 	defer s.Close()
 
-	cfg := config.Config{
+	cfg := config.ServerConfig{
 		ServerType: config.SERVER_TYPE_FORGE,
 		RCON: config.RCONConfig{
 			Host:     "localhost",
@@ -68,7 +68,7 @@ func TestRCONCollectorCollect(t *testing.T) {
 	s, port := newTestServer(t)
 	defer s.Close()
 
-	cfg := config.Config{
+	cfg := config.ServerConfig{
 		ServerType: config.SERVER_TYPE_FORGE,
 		RCON: config.RCONConfig{
 			Host:     "localhost",
@@ -99,7 +99,7 @@ func TestNewRCONCollector(t *testing.T) {
 	assert := assert.New(t)
 
 	// Test successful creation
-	cfg := config.Config{
+	cfg := config.ServerConfig{
 		ServerType: config.SERVER_TYPE_FORGE,
 		RCON: config.RCONConfig{
 			Enable:   true,
@@ -125,7 +125,7 @@ func TestNewRCONCollector(t *testing.T) {
 func TestRCONCollectorClient(t *testing.T) {
 	assert := assert.New(t)
 
-	cfg := config.Config{
+	cfg := config.ServerConfig{
 		RCON: config.RCONConfig{
 			Host:     "localhost",
 			Port:     25575,
@@ -146,7 +146,7 @@ func TestRCONCollectorClient(t *testing.T) {
 func TestRCONCollectorClose(t *testing.T) {
 	assert := assert.New(t)
 
-	cfg := config.Config{
+	cfg := config.ServerConfig{
 		RCON: config.RCONConfig{
 			Host:     "localhost",
 			Port:     25575,
@@ -0,0 +1,56 @@
+package rcon
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"net"
+	"time"
+
+	mcnet "github.com/Tnze/go-mc/net"
+)
+
+// Connect and login to the RCON server.
+// Works like net.DialRCON from go-mc, but does not block forever when the server does not answer.
+func dialRCON(addr, password string, timeout time.Duration) (*mcnet.RCONConn, error) {
+	conn, err := net.DialTimeout("tcp", addr, timeout)
+	if err != nil {
+		return nil, fmt.Errorf("connect fail: %w", err)
+	}
+	c := &mcnet.RCONConn{Conn: conn, ReqID: rand.Int31()}
+
+	err = conn.SetDeadline(time.Now().Add(timeout))
+	if err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+
+	err = c.WritePacket(c.ReqID, 3, password)
+	if err != nil {
+		_ = conn.Close()
+		return nil, fmt.Errorf("login fail: %w", err)
+	}
+
+	reqID, _, _, err := c.ReadPacket()
+	if err != nil {
+		_ = conn.Close()
+		return nil, fmt.Errorf("read login resp fail: %w", err)
+	}
+	switch reqID {
+	case c.ReqID:
+	case -1:
+		_ = conn.Close()
+		return nil, errors.New("login fail")
+	default:
+		_ = conn.Close()
+		return nil, errors.New("req id not match")
+	}
+
+	// Commands have their own timeout
+	err = conn.SetDeadline(time.Time{})
+	if err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+	return c, nil
+}
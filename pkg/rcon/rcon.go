@@ -10,10 +10,14 @@ import (
 	"github.com/heathcliff26/minecraft-exporter/pkg/utils"
 )
 
+// Time to wait for the connection and login to the minecraft server
+const DEFAULT_DIAL_TIMEOUT = 10 * time.Second
+
 type RCONClient struct {
-	addr     string
-	password string
-	conn     net.RCONClientConn
+	addr        string
+	password    string
+	dialTimeout time.Duration
+	conn        net.RCONClientConn
 
 	version     string
 	versionLock sync.RWMutex
@@ -33,15 +37,16 @@ func NewRCONClient(host string, port int, password string) (*RCONClient, error)
 
 	addr := host + ":" + strconv.Itoa(port)
 	return &RCONClient{
-		addr:     addr,
-		password: password,
+		addr:        addr,
+		password:    password,
+		dialTimeout: DEFAULT_DIAL_TIMEOUT,
 	}, nil
 }
 
 // Create a RCON Connection with the minecraft server
 func (c *RCONClient) createConnection() error {
 	slog.Debug("Creating new RCON connection")
-	client, err := net.DialRCON(c.addr, c.password)
+	client, err := dialRCON(c.addr, c.password, c.dialTimeout)
 	if err != nil {
 		return err
 	}
@@ -50,6 +55,20 @@ func (c *RCONClient) createConnection() error {
 	return nil
 }
 
+// Set the time to wait for the connection and login to the minecraft server
+func (c *RCONClient) SetDialTimeout(timeout time.Duration) {
+	c.dialTimeout = timeout
+}
+
+// Connect to the minecraft server, if not already connected.
+// Can be used to check if the server is reachable and the password is correct.
+func (c *RCONClient) Connect() error {
+	if c.conn != nil {
+		return nil
+	}
+	return c.createConnection()
+}
+
 // Execute a remote command
 func (c *RCONClient) cmd(cmd string) (string, error) {
 	if c.conn == nil {
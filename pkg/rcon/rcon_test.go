@@ -154,6 +154,44 @@ func TestTimeout(t *testing.T) {
 	assert.Equal("rcon.ErrRCONConnectionTimeout", reflect.TypeOf(err).String())
 }
 
+func TestDialTimeout(t *testing.T) {
+	s, err := net.ListenRCON("localhost:0")
+	if err != nil {
+		t.Fatalf("Failed to create RCON server: %v", err)
+	}
+	t.Cleanup(func() { _ = s.Close() })
+
+	assert := assert.New(t)
+
+	go func() {
+		// Accept the connection, but never answer the login
+		conn, err := s.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		time.Sleep(3 * time.Second)
+	}()
+
+	addr := strings.Split(s.Listener.Addr().String(), ":")
+	port, err := strconv.Atoi(addr[1])
+	if err != nil {
+		t.Fatalf("Failed to convert addr to port: %v", err)
+	}
+
+	c, err := NewRCONClient(addr[0], port, "password")
+	if err != nil {
+		t.Fatalf("Failed to create RCON client: %v", err)
+	}
+	c.SetDialTimeout(100 * time.Millisecond)
+
+	start := time.Now()
+	err = c.Connect()
+	assert.Error(err, "Should fail when the server does not answer the login")
+	assert.Less(time.Since(start), time.Second, "Should not wait longer than the timeout")
+	assert.Nil(c.conn, "Should not keep the connection")
+}
+
 func TestUpdateVersion(t *testing.T) {
 	c := &RCONClient{}
 
@@ -0,0 +1,228 @@
+package save
+
+import (
+	"strings"
+)
+
+// Data versions of the releases that changed the vanilla advancements
+const (
+	DATA_VERSION_1_12   = 1139
+	DATA_VERSION_1_13   = 1519
+	DATA_VERSION_1_14   = 1952
+	DATA_VERSION_1_15   = 2225
+	DATA_VERSION_1_16   = 2566
+	DATA_VERSION_1_17   = 2724
+	DATA_VERSION_1_18   = 2860
+	DATA_VERSION_1_19   = 3105
+	DATA_VERSION_1_20   = 3463
+	DATA_VERSION_1_20_5 = 3837
+	DATA_VERSION_1_21   = 3953
+	DATA_VERSION_1_21_4 = 4189
+	DATA_VERSION_1_21_6 = 4435
+)
+
+// Tabs of the vanilla advancements
+var advancementTabs = []string{"story", "nether", "end", "adventure", "husbandry"}
+
+type vanillaAdvancement struct {
+	// Data version of the release that added the advancement
+	Added int
+	// Data version of the release that removed the advancement, 0 if it still exists
+	Removed int
+	// Requires multiple criteria to be completed, e.g. visiting all biomes
+	MultiCriteria bool
+}
+
+// Catalog of the vanilla advancements
+var vanillaAdvancements = map[string]vanillaAdvancement{
+	"minecraft:story/root":                 {Added: DATA_VERSION_1_12},
+	"minecraft:story/mine_stone":           {Added: DATA_VERSION_1_12},
+	"minecraft:story/upgrade_tools":        {Added: DATA_VERSION_1_12},
+	"minecraft:story/smelt_iron":           {Added: DATA_VERSION_1_12},
+	"minecraft:story/obtain_armor":         {Added: DATA_VERSION_1_12},
+	"minecraft:story/lava_bucket":          {Added: DATA_VERSION_1_12},
+	"minecraft:story/iron_tools":           {Added: DATA_VERSION_1_12},
+	"minecraft:story/deflect_arrow":        {Added: DATA_VERSION_1_12},
+	"minecraft:story/form_obsidian":        {Added: DATA_VERSION_1_12},
+	"minecraft:story/mine_diamond":         {Added: DATA_VERSION_1_12},
+	"minecraft:story/enter_the_nether":     {Added: DATA_VERSION_1_12},
+	"minecraft:story/shiny_gear":           {Added: DATA_VERSION_1_12},
+	"minecraft:story/enchant_item":         {Added: DATA_VERSION_1_12},
+	"minecraft:story/cure_zombie_villager": {Added: DATA_VERSION_1_12},
+	"minecraft:story/follow_ender_eye":     {Added: DATA_VERSION_1_12},
+	"minecraft:story/enter_the_end":        {Added: DATA_VERSION_1_12},
+
+	"minecraft:nether/root":                           {Added: DATA_VERSION_1_12},
+	"minecraft:nether/return_to_sender":               {Added: DATA_VERSION_1_12},
+	"minecraft:nether/find_fortress":                  {Added: DATA_VERSION_1_12},
+	"minecraft:nether/fast_travel":                    {Added: DATA_VERSION_1_12},
+	"minecraft:nether/uneasy_alliance":                {Added: DATA_VERSION_1_12},
+	"minecraft:nether/get_wither_skull":               {Added: DATA_VERSION_1_12},
+	"minecraft:nether/summon_wither":                  {Added: DATA_VERSION_1_12},
+	"minecraft:nether/obtain_blaze_rod":               {Added: DATA_VERSION_1_12},
+	"minecraft:nether/brew_potion":                    {Added: DATA_VERSION_1_12},
+	"minecraft:nether/create_beacon":                  {Added: DATA_VERSION_1_12},
+	"minecraft:nether/all_potions":                    {Added: DATA_VERSION_1_12, MultiCriteria: true},
+	"minecraft:nether/create_full_beacon":             {Added: DATA_VERSION_1_12},
+	"minecraft:nether/all_effects":                    {Added: DATA_VERSION_1_12, MultiCriteria: true},
+	"minecraft:nether/find_bastion":                   {Added: DATA_VERSION_1_16},
+	"minecraft:nether/obtain_ancient_debris":          {Added: DATA_VERSION_1_16},
+	"minecraft:nether/obtain_crying_obsidian":         {Added: DATA_VERSION_1_16},
+	"minecraft:nether/distract_piglin":                {Added: DATA_VERSION_1_16},
+	"minecraft:nether/ride_strider":                   {Added: DATA_VERSION_1_16},
+	"minecraft:nether/loot_bastion":                   {Added: DATA_VERSION_1_16},
+	"minecraft:nether/use_lodestone":                  {Added: DATA_VERSION_1_16},
+	"minecraft:nether/netherite_armor":                {Added: DATA_VERSION_1_16},
+	"minecraft:nether/charge_respawn_anchor":          {Added: DATA_VERSION_1_16},
+	"minecraft:nether/explore_nether":                 {Added: DATA_VERSION_1_16, MultiCriteria: true},
+	"minecraft:nether/ride_strider_in_overworld_lava": {Added: DATA_VERSION_1_17},
+
+	"minecraft:end/root":              {Added: DATA_VERSION_1_12},
+	"minecraft:end/kill_dragon":       {Added: DATA_VERSION_1_12},
+	"minecraft:end/dragon_egg":        {Added: DATA_VERSION_1_12},
+	"minecraft:end/enter_end_gateway": {Added: DATA_VERSION_1_12},
+	"minecraft:end/respawn_dragon":    {Added: DATA_VERSION_1_12},
+	"minecraft:end/dragon_breath":     {Added: DATA_VERSION_1_12},
+	"minecraft:end/find_end_city":     {Added: DATA_VERSION_1_12},
+	"minecraft:end/elytra":            {Added: DATA_VERSION_1_12},
+	"minecraft:end/levitate":          {Added: DATA_VERSION_1_12},
+
+	"minecraft:adventure/root":                                   {Added: DATA_VERSION_1_12},
+	"minecraft:adventure/kill_a_mob":                             {Added: DATA_VERSION_1_12},
+	"minecraft:adventure/trade":                                  {Added: DATA_VERSION_1_14},
+	"minecraft:adventure/sleep_in_bed":                           {Added: DATA_VERSION_1_12},
+	"minecraft:adventure/shoot_arrow":                            {Added: DATA_VERSION_1_12},
+	"minecraft:adventure/kill_all_mobs":                          {Added: DATA_VERSION_1_12, MultiCriteria: true},
+	"minecraft:adventure/totem_of_undying":                       {Added: DATA_VERSION_1_12},
+	"minecraft:adventure/summon_iron_golem":                      {Added: DATA_VERSION_1_14},
+	"minecraft:adventure/adventuring_time":                       {Added: DATA_VERSION_1_12, MultiCriteria: true},
+	"minecraft:adventure/sniper_duel":                            {Added: DATA_VERSION_1_12},
+	"minecraft:adventure/throw_trident":                          {Added: DATA_VERSION_1_13},
+	"minecraft:adventure/very_very_frightening":                  {Added: DATA_VERSION_1_13},
+	"minecraft:adventure/voluntary_exile":                        {Added: DATA_VERSION_1_14},
+	"minecraft:adventure/hero_of_the_village":                    {Added: DATA_VERSION_1_14},
+	"minecraft:adventure/ol_betsy":                               {Added: DATA_VERSION_1_14},
+	"minecraft:adventure/whos_the_pillager_now":                  {Added: DATA_VERSION_1_14},
+	"minecraft:adventure/two_birds_one_arrow":                    {Added: DATA_VERSION_1_14},
+	"minecraft:adventure/arbalistic":                             {Added: DATA_VERSION_1_14},
+	"minecraft:adventure/honey_block_slide":                      {Added: DATA_VERSION_1_15},
+	"minecraft:adventure/bullseye":                               {Added: DATA_VERSION_1_16},
+	"minecraft:adventure/spyglass_at_parrot":                     {Added: DATA_VERSION_1_17},
+	"minecraft:adventure/spyglass_at_ghast":                      {Added: DATA_VERSION_1_17},
+	"minecraft:adventure/spyglass_at_dragon":                     {Added: DATA_VERSION_1_17},
+	"minecraft:adventure/lightning_rod_with_villager_no_fire":    {Added: DATA_VERSION_1_17},
+	"minecraft:adventure/fall_from_world_height":                 {Added: DATA_VERSION_1_17},
+	"minecraft:adventure/walk_on_powder_snow_with_leather_boots": {Added: DATA_VERSION_1_17},
+	"minecraft:adventure/play_jukebox_in_meadows":                {Added: DATA_VERSION_1_18},
+	"minecraft:adventure/trade_at_world_height":                  {Added: DATA_VERSION_1_18},
+	"minecraft:adventure/kill_mob_near_sculk_catalyst":           {Added: DATA_VERSION_1_19},
+	"minecraft:adventure/avoid_vibration":                        {Added: DATA_VERSION_1_19},
+	"minecraft:adventure/trim_with_any_armor_pattern":            {Added: DATA_VERSION_1_20},
+	"minecraft:adventure/trim_with_all_exclusive_armor_patterns": {Added: DATA_VERSION_1_20, MultiCriteria: true},
+	"minecraft:adventure/salvage_sherd":                          {Added: DATA_VERSION_1_20},
+	"minecraft:adventure/craft_decorated_pot_using_only_sherds":  {Added: DATA_VERSION_1_20},
+	"minecraft:adventure/read_power_of_chiseled_bookshelf":       {Added: DATA_VERSION_1_20},
+	"minecraft:adventure/minecraft_trials_edition":               {Added: DATA_VERSION_1_21},
+	"minecraft:adventure/crafters_crafting_crafters":             {Added: DATA_VERSION_1_21},
+	"minecraft:adventure/lighten_up":                             {Added: DATA_VERSION_1_21},
+	"minecraft:adventure/who_needs_rockets":                      {Added: DATA_VERSION_1_21},
+	"minecraft:adventure/under_lock_and_key":                     {Added: DATA_VERSION_1_21},
+	"minecraft:adventure/revaulting":                             {Added: DATA_VERSION_1_21},
+	"minecraft:adventure/blowback":                               {Added: DATA_VERSION_1_21},
+	"minecraft:adventure/overoverkill":                           {Added: DATA_VERSION_1_21},
+	"minecraft:adventure/heart_transplanter":                     {Added: DATA_VERSION_1_21_4},
+
+	"minecraft:husbandry/root":                             {Added: DATA_VERSION_1_12},
+	"minecraft:husbandry/breed_an_animal":                  {Added: DATA_VERSION_1_12},
+	"minecraft:husbandry/tame_an_animal":                   {Added: DATA_VERSION_1_12},
+	"minecraft:husbandry/plant_seed":                       {Added: DATA_VERSION_1_12},
+	"minecraft:husbandry/bred_all_animals":                 {Added: DATA_VERSION_1_12, MultiCriteria: true},
+	"minecraft:husbandry/balanced_diet":                    {Added: DATA_VERSION_1_12, MultiCriteria: true},
+	"minecraft:husbandry/break_diamond_hoe":                {Added: DATA_VERSION_1_12, Removed: DATA_VERSION_1_16},
+	"minecraft:husbandry/complete_catalogue":               {Added: DATA_VERSION_1_14, MultiCriteria: true},
+	"minecraft:husbandry/fishy_business":                   {Added: DATA_VERSION_1_13},
+	"minecraft:husbandry/tactical_fishing":                 {Added: DATA_VERSION_1_13},
+	"minecraft:husbandry/safely_harvest_honey":             {Added: DATA_VERSION_1_15},
+	"minecraft:husbandry/silk_touch_nest":                  {Added: DATA_VERSION_1_15},
+	"minecraft:husbandry/obtain_netherite_hoe":             {Added: DATA_VERSION_1_16},
+	"minecraft:husbandry/ride_a_boat_with_a_goat":          {Added: DATA_VERSION_1_17},
+	"minecraft:husbandry/make_a_sign_glow":                 {Added: DATA_VERSION_1_17},
+	"minecraft:husbandry/axolotl_in_a_bucket":              {Added: DATA_VERSION_1_17},
+	"minecraft:husbandry/kill_axolotl_target":              {Added: DATA_VERSION_1_17},
+	"minecraft:husbandry/wax_on":                           {Added: DATA_VERSION_1_17},
+	"minecraft:husbandry/wax_off":                          {Added: DATA_VERSION_1_17},
+	"minecraft:husbandry/tadpole_in_a_bucket":              {Added: DATA_VERSION_1_19},
+	"minecraft:husbandry/leash_all_frog_variants":          {Added: DATA_VERSION_1_19, MultiCriteria: true},
+	"minecraft:husbandry/froglights":                       {Added: DATA_VERSION_1_19, MultiCriteria: true},
+	"minecraft:husbandry/allay_deliver_item_to_player":     {Added: DATA_VERSION_1_19},
+	"minecraft:husbandry/allay_deliver_cake_to_note_block": {Added: DATA_VERSION_1_19},
+	"minecraft:husbandry/plant_any_sniffer_seed":           {Added: DATA_VERSION_1_20},
+	"minecraft:husbandry/feed_snifflet":                    {Added: DATA_VERSION_1_20},
+	"minecraft:husbandry/obtain_sniffer_egg":               {Added: DATA_VERSION_1_20},
+	"minecraft:husbandry/brush_armadillo":                  {Added: DATA_VERSION_1_20_5},
+	"minecraft:husbandry/remove_wolf_armor":                {Added: DATA_VERSION_1_20_5},
+	"minecraft:husbandry/repair_wolf_armor":                {Added: DATA_VERSION_1_20_5},
+	"minecraft:husbandry/whole_pack":                       {Added: DATA_VERSION_1_20_5, MultiCriteria: true},
+	"minecraft:husbandry/place_dried_ghast_in_water":       {Added: DATA_VERSION_1_21_6},
+}
+
+type AdvancementTabProgress struct {
+	// Completed advancements of the tab
+	Done int
+	// Number of advancements in the tab
+	Total int
+}
+
+// Return the completion percentage of the tab
+func (p AdvancementTabProgress) Percent() float64 {
+	if p.Total == 0 {
+		return 0
+	}
+	return float64(p.Done) / float64(p.Total) * 100
+}
+
+// Check if the advancement exists in the given data version
+func (a vanillaAdvancement) existsIn(dataVersion int) bool {
+	return dataVersion >= a.Added && (a.Removed == 0 || dataVersion < a.Removed)
+}
+
+// Return the tab of a vanilla advancement
+func advancementTab(id string) string {
+	tab, _, _ := strings.Cut(strings.TrimPrefix(id, "minecraft:"), "/")
+	return tab
+}
+
+// Calculate the progress of the player in each vanilla advancement tab.
+// Only advancements that exist in the given data version are considered.
+func advancementTabProgress(dataVersion int, advancements map[string]Advancement) map[string]AdvancementTabProgress {
+	progress := make(map[string]AdvancementTabProgress, len(advancementTabs))
+	for _, tab := range advancementTabs {
+		progress[tab] = AdvancementTabProgress{}
+	}
+
+	for id, vanilla := range vanillaAdvancements {
+		if !vanilla.existsIn(dataVersion) {
+			continue
+		}
+		tab := advancementTab(id)
+		p := progress[tab]
+		p.Total++
+		if advancements[id].Done {
+			p.Done++
+		}
+		progress[tab] = p
+	}
+	return progress
+}
+
+// Return the number of criteria the player has met for each vanilla advancement that requires multiple criteria.
+func advancementCriteriaProgress(dataVersion int, advancements map[string]Advancement) map[string]int {
+	result := make(map[string]int)
+	for id, vanilla := range vanillaAdvancements {
+		if !vanilla.MultiCriteria || !vanilla.existsIn(dataVersion) {
+			continue
+		}
+		result[id] = len(advancements[id].Criteria)
+	}
+	return result
+}
@@ -0,0 +1,57 @@
+package save
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdvancementTabProgress(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	s, err := NewSave("./testdata/1.20")
+	require.NoError(err, "Should create save")
+	advancements, err := s.loadAdvancements(testUUID)
+	require.NoError(err, "Should load advancements")
+
+	expected := map[string]AdvancementTabProgress{
+		"story":     {Done: 12, Total: 16},
+		"nether":    {Done: 1, Total: 24},
+		"end":       {Done: 1, Total: 9},
+		"adventure": {Done: 3, Total: 35},
+		"husbandry": {Done: 4, Total: 26},
+	}
+	progress := advancementTabProgress(s.Version.Id, advancements)
+	assert.Equal(expected, progress)
+	assert.Equal(float64(75), progress["story"].Percent())
+
+	criteria := advancementCriteriaProgress(s.Version.Id, advancements)
+	assert.Equal(9, criteria["minecraft:adventure/adventuring_time"], "Should count visited biomes")
+	assert.Equal(6, criteria["minecraft:adventure/kill_all_mobs"], "Should count killed mobs")
+	assert.Equal(0, criteria["minecraft:husbandry/whole_pack"], "Should report advancements without progress")
+	assert.NotContains(criteria, "minecraft:story/mine_stone", "Should only contain advancements with multiple criteria")
+}
+
+func TestAdvancementCatalogByVersion(t *testing.T) {
+	assert := assert.New(t)
+
+	progress := advancementTabProgress(1343, nil)
+	assert.Equal(7, progress["husbandry"].Total, "Should include advancements removed in later versions")
+	assert.Equal(13, progress["nether"].Total, "Should not include advancements added in later versions")
+
+	before, after := advancementTabProgress(DATA_VERSION_1_13, nil), advancementTabProgress(DATA_VERSION_1_14, nil)
+	assert.Equal(8, after["adventure"].Total-before["adventure"].Total, "Should include the adventure advancements added in 1.14")
+	assert.Equal(1, after["husbandry"].Total-before["husbandry"].Total, "Should include the husbandry advancements added in 1.14")
+	progress = advancementTabProgress(DATA_VERSION_1_13, map[string]Advancement{"minecraft:adventure/trade": {Done: true}})
+	assert.Equal(0, progress["adventure"].Done, "Should not count advancements added in later versions")
+
+	progress = advancementTabProgress(DATA_VERSION_1_16, nil)
+	assert.Equal(12, progress["husbandry"].Total, "Should exclude removed advancements")
+
+	added := advancementTabProgress(DATA_VERSION_1_20_5, nil)["husbandry"].Total - advancementTabProgress(DATA_VERSION_1_20, nil)["husbandry"].Total
+	assert.Equal(4, added, "Should include the husbandry advancements added in 1.20.5")
+
+	assert.Equal(AdvancementTabProgress{}.Percent(), float64(0), "Should not divide by zero")
+}
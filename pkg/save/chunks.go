@@ -0,0 +1,191 @@
+package save
+
+import (
+	"errors"
+	"math"
+	"os"
+)
+
+const (
+	CHUNK_STATUS_FULL    = "full"
+	CHUNK_STATUS_UNKNOWN = "unknown"
+
+	// The chunk shares sectors with another chunk
+	CORRUPTION_OVERLAP = "overlap"
+	// The chunk points outside of the file or has an invalid length
+	CORRUPTION_INVALID_LOCATION = "invalid_location"
+	// The external .mcc file of the chunk is missing
+	CORRUPTION_MISSING_EXTERNAL = "missing_external"
+	// The chunk payload could not be decompressed
+	CORRUPTION_DECOMPRESSION = "decompression"
+	// The decompressed chunk does not contain valid nbt data
+	CORRUPTION_NBT = "nbt"
+)
+
+var corruptionReasons = []string{CORRUPTION_OVERLAP, CORRUPTION_INVALID_LOCATION, CORRUPTION_MISSING_EXTERNAL, CORRUPTION_DECOMPRESSION, CORRUPTION_NBT}
+
+// Coordinate scale of the vanilla dimensions, relative to the overworld
+var dimensionCoordinateScale = map[string]float64{
+	DIMENSION_THE_NETHER: 8,
+}
+
+// Counts the chunks of the world by status and checks them for corruption, based on the shared region scanner.
+type ChunkScanner struct {
+	regions *RegionScanner
+}
+
+// Chunk statistics of a scanned region file
+type chunkRegion struct {
+	// Number of chunks by status
+	statuses map[string]int
+	// Positions of the fully generated chunks
+	generated []ChunkPos
+	// Number of corrupted chunks by reason
+	corrupted map[string]int
+	// Number of chunks stored in external .mcc files
+	oversized int
+	// Ticks players have spent inside each chunk, only contains inhabited chunks
+	inhabited map[ChunkPos]int64
+}
+
+type ChunkScanResult struct {
+	// Number of chunks by dimension and status
+	Statuses map[string]map[string]int
+	// Percentage of the area inside the world border that has been fully generated, by dimension.
+	// Only contains values when the world border is stored in level.dat.
+	Coverage map[string]float64
+	// Number of region files with an invalid name or header, by dimension
+	InvalidRegions map[string]int
+	// Number of corrupted chunks by dimension and reason
+	Corrupted map[string]map[string]int
+	// Number of chunks stored in external .mcc files, by dimension
+	Oversized map[string]int
+	// Ticks players have spent inside the chunks of each grid cell, by dimension
+	InhabitedTime map[string]map[ChunkPos]int64
+}
+
+func NewChunkScanner(regions *RegionScanner) *ChunkScanner {
+	return &ChunkScanner{
+		regions: regions,
+	}
+}
+
+// Scan the chunks of all dimensions.
+// The inhabited time is summed up in square cells with gridSize chunks per side, it is skipped when gridSize is 0.
+func (s *ChunkScanner) Scan(gridSize int) (ChunkScanResult, error) {
+	level, err := s.regions.save.LoadLevelData()
+	if err != nil {
+		return ChunkScanResult{}, err
+	}
+
+	dimensions, err := s.regions.scan()
+	if err != nil {
+		return ChunkScanResult{}, err
+	}
+	result := ChunkScanResult{
+		Statuses:       make(map[string]map[string]int, len(dimensions)),
+		Coverage:       make(map[string]float64, len(dimensions)),
+		InvalidRegions: make(map[string]int, len(dimensions)),
+		Corrupted:      make(map[string]map[string]int, len(dimensions)),
+		Oversized:      make(map[string]int, len(dimensions)),
+		InhabitedTime:  make(map[string]map[ChunkPos]int64, len(dimensions)),
+	}
+	for _, dim := range dimensions {
+		statuses := make(map[string]int)
+		corrupted := make(map[string]int, len(corruptionReasons))
+		for _, reason := range corruptionReasons {
+			corrupted[reason] = 0
+		}
+		var generated []ChunkPos
+		inhabited := make(map[ChunkPos]int64)
+		result.InvalidRegions[dim.name] = dim.invalid
+		result.Oversized[dim.name] = 0
+		for _, region := range dim.regions {
+			for status, count := range region.statuses {
+				statuses[status] += count
+			}
+			for reason, count := range region.corrupted {
+				corrupted[reason] += count
+			}
+			result.Oversized[dim.name] += region.oversized
+			generated = append(generated, region.generated...)
+			if gridSize > 0 {
+				for pos, ticks := range region.inhabited {
+					cell := ChunkPos{X: floorDiv(pos.X, gridSize), Z: floorDiv(pos.Z, gridSize)}
+					inhabited[cell] += ticks
+				}
+			}
+		}
+		result.Statuses[dim.name] = statuses
+		result.Corrupted[dim.name] = corrupted
+		if gridSize > 0 {
+			result.InhabitedTime[dim.name] = inhabited
+		}
+
+		if level.BorderSize != nil {
+			result.Coverage[dim.name] = borderCoverage(level.BorderCenterX, level.BorderCenterZ, *level.BorderSize, dim.name, generated)
+		}
+	}
+
+	return result, nil
+}
+
+// Classify the error returned when reading a chunk.
+// Returns false if the chunk is not corrupted, e.g. because the compression is not supported.
+func corruptionReason(err error) (string, bool) {
+	var invalidChunk *ErrInvalidChunk
+	var decompressChunk *ErrDecompressChunk
+	var decodeChunk *ErrDecodeChunk
+	switch {
+	case errors.As(err, &invalidChunk):
+		return CORRUPTION_INVALID_LOCATION, true
+	case os.IsNotExist(err):
+		return CORRUPTION_MISSING_EXTERNAL, true
+	case errors.As(err, &decompressChunk):
+		return CORRUPTION_DECOMPRESSION, true
+	case errors.As(err, &decodeChunk):
+		return CORRUPTION_NBT, true
+	default:
+		// Unsupported compression types and failures to read external files are not a sign of corruption
+		return "", false
+	}
+}
+
+// Calculate the percentage of chunks inside the world border that have been generated.
+// The border is scaled by the coordinate scale of the dimension, chunks that are partially inside the border count as well.
+func borderCoverage(centerX, centerZ, size float64, dimension string, generated []ChunkPos) float64 {
+	scale := dimensionCoordinateScale[dimension]
+	if scale == 0 {
+		scale = 1
+	}
+	centerX, centerZ, size = centerX/scale, centerZ/scale, size/scale
+
+	minX, maxX := borderChunkRange(centerX, size)
+	minZ, maxZ := borderChunkRange(centerZ, size)
+	total := float64(maxX-minX+1) * float64(maxZ-minZ+1)
+	if total <= 0 {
+		return 0
+	}
+
+	covered := 0
+	for _, pos := range generated {
+		if pos.X >= minX && pos.X <= maxX && pos.Z >= minZ && pos.Z <= maxZ {
+			covered++
+		}
+	}
+	return float64(covered) / total * 100
+}
+
+// Return the first and last chunk coordinate on an axis that are inside the border
+func borderChunkRange(center, size float64) (int, int) {
+	return int(math.Floor((center - size/2) / 16)), int(math.Ceil((center+size/2)/16)) - 1
+}
+
+// Integer division that rounds towards negative infinity, to map negative coordinates to the correct cell
+func floorDiv(a, b int) int {
+	q := a / b
+	if a%b != 0 && (a < 0) != (b < 0) {
+		q--
+	}
+	return q
+}
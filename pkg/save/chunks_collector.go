@@ -0,0 +1,89 @@
+package save
+
+import (
+	"log/slog"
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type ChunkCollector struct {
+	scanner  *ChunkScanner
+	Instance string
+	// Number of chunks per side of the cells the inhabited time is summed up in, disabled when 0.
+	// Defaults to the size of a region.
+	InhabitedTimeGrid int
+}
+
+var (
+	mcWorldChunkStatusDesc = prometheus.NewDesc("minecraft_world_chunk_status", "Number of chunks of a dimension by generation status", append(worldVariableLabels, "status"), nil)
+	mcWorldPregenDesc      = prometheus.NewDesc("minecraft_world_pregeneration_coverage_percent", "Percentage of the area inside the world border that has been fully generated", worldVariableLabels, nil)
+
+	mcWorldInvalidRegionsDesc  = prometheus.NewDesc("minecraft_world_invalid_region_files", "Number of region files of a dimension with an invalid header", worldVariableLabels, nil)
+	mcWorldCorruptedChunksDesc = prometheus.NewDesc("minecraft_world_corrupted_chunks", "Number of corrupted chunks of a dimension by reason", append(worldVariableLabels, "reason"), nil)
+	mcWorldInhabitedTimeDesc   = prometheus.NewDesc("minecraft_world_inhabited_time_ticks", "Total ticks players have spent in the chunks of a grid cell, by default the cells match the region files", append(worldVariableLabels, "x", "z"), nil)
+	mcWorldOversizedChunksDesc = prometheus.NewDesc("minecraft_world_oversized_chunks", "Number of chunks of a dimension that are too large for the region file and stored in external .mcc files", worldVariableLabels, nil)
+)
+
+// Create new instance of collector
+// Arguments:
+//
+//	regions: The region scanner of the world, shared with the other collectors of the world
+//	instance: The instance label to use for the metrics
+func NewChunkCollector(regions *RegionScanner, instance string) *ChunkCollector {
+	return &ChunkCollector{
+		scanner:           NewChunkScanner(regions),
+		Instance:          instance,
+		InhabitedTimeGrid: REGION_CHUNKS_PER_AX,
+	}
+}
+
+// Implements the Describe function for prometheus.Collector
+func (c *ChunkCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- mcWorldChunkStatusDesc
+	ch <- mcWorldPregenDesc
+	ch <- mcWorldInvalidRegionsDesc
+	ch <- mcWorldCorruptedChunksDesc
+	ch <- mcWorldOversizedChunksDesc
+	if c.InhabitedTimeGrid > 0 {
+		ch <- mcWorldInhabitedTimeDesc
+	}
+}
+
+// Implements the Collect function for prometheus.Collector
+func (c *ChunkCollector) Collect(ch chan<- prometheus.Metric) {
+	slog.Debug("Starting collection of chunks")
+
+	result, err := c.scanner.Scan(c.InhabitedTimeGrid)
+	if err != nil {
+		slog.Error("Failed to scan the chunks of the world", "err", err)
+		return
+	}
+
+	for dim, statuses := range result.Statuses {
+		for status, count := range statuses {
+			ch <- prometheus.MustNewConstMetric(mcWorldChunkStatusDesc, prometheus.GaugeValue, float64(count), c.Instance, dim, status)
+		}
+	}
+	for dim, coverage := range result.Coverage {
+		ch <- prometheus.MustNewConstMetric(mcWorldPregenDesc, prometheus.GaugeValue, coverage, c.Instance, dim)
+	}
+	for dim, count := range result.InvalidRegions {
+		ch <- prometheus.MustNewConstMetric(mcWorldInvalidRegionsDesc, prometheus.GaugeValue, float64(count), c.Instance, dim)
+	}
+	for dim, reasons := range result.Corrupted {
+		for reason, count := range reasons {
+			ch <- prometheus.MustNewConstMetric(mcWorldCorruptedChunksDesc, prometheus.GaugeValue, float64(count), c.Instance, dim, reason)
+		}
+	}
+	for dim, count := range result.Oversized {
+		ch <- prometheus.MustNewConstMetric(mcWorldOversizedChunksDesc, prometheus.GaugeValue, float64(count), c.Instance, dim)
+	}
+	for dim, cells := range result.InhabitedTime {
+		for cell, ticks := range cells {
+			ch <- prometheus.MustNewConstMetric(mcWorldInhabitedTimeDesc, prometheus.GaugeValue, float64(ticks), c.Instance, dim, strconv.Itoa(cell.X), strconv.Itoa(cell.Z))
+		}
+	}
+
+	slog.Debug("Finished collection of chunks")
+}
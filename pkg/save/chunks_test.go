@@ -0,0 +1,213 @@
+package save
+
+import (
+	"encoding/binary"
+	"os"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChunkScanner(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	path := newTestWorld(t, "1.20")
+	writeTestRegion(t, path+REGION_DIR+"/r.0.0.mca", []testChunk{
+		{X: 0, Z: 0, Data: map[string]any{"Status": "minecraft:full"}},
+		{X: 1, Z: 0, Data: map[string]any{"Status": "minecraft:full"}},
+		{X: 2, Z: 0, Data: map[string]any{"Status": "minecraft:features"}},
+		{X: 3, Z: 0, Data: map[string]any{"Status": "minecraft:empty"}},
+	})
+	writeTestRegion(t, path+NETHER_DIR_LEGACY+REGION_DIR+"/r.0.0.mca", []testChunk{
+		{X: 0, Z: 0, Data: map[string]any{"Level": map[string]any{"Status": "full"}}},
+		{X: 1, Z: 0, Data: map[string]any{"Level": map[string]any{"Status": "postprocessed"}}},
+		{X: 2, Z: 0, Data: map[string]any{"Level": map[string]any{}}},
+	})
+
+	s, err := NewSave(path)
+	require.NoError(err, "Should create save")
+
+	result, err := NewChunkScanner(newRegionScanner(s, nil)).Scan(0)
+	require.NoError(err, "Should scan chunks")
+
+	expected := map[string]map[string]int{
+		DIMENSION_OVERWORLD:  {"full": 2, "features": 1, "empty": 1},
+		DIMENSION_THE_NETHER: {"full": 1, "postprocessed": 1, "unknown": 1},
+	}
+	assert.Equal(expected, result.Statuses, "Should count chunks by status")
+
+	// The border of the test world has a diameter of 6528 blocks
+	assert.InDelta(2.0/(408*408)*100, result.Coverage[DIMENSION_OVERWORLD], 1e-9, "Should calculate coverage of the overworld")
+	assert.InDelta(1.0/(52*52)*100, result.Coverage[DIMENSION_THE_NETHER], 1e-9, "Should scale the border in the nether")
+}
+
+func TestChunkScannerWithoutBorder(t *testing.T) {
+	require := require.New(t)
+
+	path := newTestWorld(t, "26")
+	writeTestRegion(t, path+DIMENSIONS_DIR+DIMENSION_DIR_OVERWORLD+REGION_DIR+"/r.0.0.mca", []testChunk{
+		{X: 0, Z: 0, Data: map[string]any{"Status": "minecraft:full"}},
+	})
+
+	s, err := NewSave(path)
+	require.NoError(err, "Should create save")
+
+	result, err := NewChunkScanner(newRegionScanner(s, nil)).Scan(0)
+	require.NoError(err, "Should scan chunks")
+	require.Equal(map[string]int{"full": 1}, result.Statuses[DIMENSION_OVERWORLD])
+	require.Empty(result.Coverage, "Should not calculate coverage without world border")
+}
+
+func TestChunkScannerCorruption(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	path := newTestWorld(t, "1.20")
+	region := path + REGION_DIR + "/r.0.0.mca"
+	writeTestRegion(t, region, []testChunk{
+		{X: 0, Z: 0, Data: map[string]any{"Status": "minecraft:full"}},
+		{X: 1, Z: 0, Data: map[string]any{"Status": "minecraft:full"}},
+		{X: 2, Z: 0, Data: map[string]any{"Status": "minecraft:full"}},
+		{X: 3, Z: 0, Data: map[string]any{"Status": "minecraft:full"}},
+		{X: 4, Z: 0, Data: map[string]any{"Status": "minecraft:full"}, External: true},
+		{X: 5, Z: 0, Data: map[string]any{"Status": "minecraft:full"}, External: true},
+		{X: 6, Z: 0, Data: map[string]any{"Status": "minecraft:full"}},
+		{X: 7, Z: 0, Raw: []byte("not nbt")},
+	})
+	data, err := os.ReadFile(region)
+	require.NoError(err)
+	// Point chunk 1 to the sectors of chunk 0
+	copy(data[4:8], data[0:4])
+	// Point chunk 2 outside of the file
+	binary.BigEndian.PutUint32(data[8:], uint32(1000<<8|1))
+	// Corrupt the compressed payload of chunk 3
+	offset := int(binary.BigEndian.Uint32(data[12:])>>8) * REGION_SECTOR_SIZE
+	copy(data[offset+5:], []byte{0xff, 0xff, 0xff, 0xff})
+	require.NoError(os.WriteFile(region, data, 0644))
+	// Remove the external file of chunk 5
+	require.NoError(os.Remove(path + REGION_DIR + "/c.5.0.mcc"))
+
+	require.NoError(os.WriteFile(path+REGION_DIR+"/r.1.0.mca", make([]byte, 100), 0644), "Should write truncated region")
+
+	s, err := NewSave(path)
+	require.NoError(err, "Should create save")
+
+	result, err := NewChunkScanner(newRegionScanner(s, nil)).Scan(0)
+	require.NoError(err, "Should not fail because of corrupted regions")
+
+	expected := map[string]int{
+		CORRUPTION_OVERLAP:          2,
+		CORRUPTION_INVALID_LOCATION: 1,
+		CORRUPTION_MISSING_EXTERNAL: 1,
+		CORRUPTION_DECOMPRESSION:    1,
+		CORRUPTION_NBT:              1,
+	}
+	assert.Equal(expected, result.Corrupted[DIMENSION_OVERWORLD], "Should count corrupted chunks by reason")
+	assert.Equal(2, result.Oversized[DIMENSION_OVERWORLD], "Should count chunks stored in external files")
+	assert.Equal(1, result.InvalidRegions[DIMENSION_OVERWORLD], "Should count invalid region files")
+	assert.Equal(map[string]int{"full": 2}, result.Statuses[DIMENSION_OVERWORLD], "Should only count readable chunks")
+}
+
+func TestChunkScannerInhabitedTime(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	path := newTestWorld(t, "1.20")
+	require.NoError(os.MkdirAll(path+NETHER_DIR_LEGACY+REGION_DIR, 0755))
+	writeTestRegion(t, path+REGION_DIR+"/r.0.0.mca", []testChunk{
+		{X: 0, Z: 0, Data: map[string]any{"Status": "minecraft:full", "InhabitedTime": int64(100)}},
+		{X: 20, Z: 0, Data: map[string]any{"Status": "minecraft:full", "InhabitedTime": int64(50)}},
+		{X: 1, Z: 1, Data: map[string]any{"Status": "minecraft:full"}},
+	})
+	writeTestRegion(t, path+REGION_DIR+"/r.-1.0.mca", []testChunk{
+		{X: 31, Z: 0, Data: map[string]any{"Level": map[string]any{"Status": "full", "InhabitedTime": int64(7)}}},
+	})
+
+	s, err := NewSave(path)
+	require.NoError(err, "Should create save")
+	scanner := NewChunkScanner(newRegionScanner(s, nil))
+
+	result, err := scanner.Scan(REGION_CHUNKS_PER_AX)
+	require.NoError(err, "Should scan chunks")
+	assert.Equal(map[ChunkPos]int64{{X: 0, Z: 0}: 150, {X: -1, Z: 0}: 7}, result.InhabitedTime[DIMENSION_OVERWORLD], "Should sum up inhabited time per region")
+	assert.Empty(result.InhabitedTime[DIMENSION_THE_NETHER], "Should not contain uninhabited cells")
+
+	result, err = scanner.Scan(16)
+	require.NoError(err, "Should scan chunks")
+	assert.Equal(map[ChunkPos]int64{{X: 0, Z: 0}: 100, {X: 1, Z: 0}: 50, {X: -1, Z: 0}: 7}, result.InhabitedTime[DIMENSION_OVERWORLD], "Should sum up inhabited time per grid cell")
+
+	result, err = scanner.Scan(0)
+	require.NoError(err, "Should scan chunks")
+	assert.Empty(result.InhabitedTime, "Should skip inhabited time when disabled")
+}
+
+func TestFloorDiv(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(0, floorDiv(31, 32))
+	assert.Equal(1, floorDiv(32, 32))
+	assert.Equal(-1, floorDiv(-1, 32))
+	assert.Equal(-1, floorDiv(-32, 32))
+	assert.Equal(-2, floorDiv(-33, 32))
+}
+
+func TestBorderCoverage(t *testing.T) {
+	generated := []ChunkPos{{X: -2, Z: -2}, {X: -1, Z: 0}, {X: 0, Z: 1}, {X: 1, Z: 1}, {X: 2, Z: 0}}
+
+	tMatrix := []struct {
+		Name             string
+		CenterX, CenterZ float64
+		Size             float64
+		Dimension        string
+		Result           float64
+	}{
+		{"Overworld", 0, 0, 64, DIMENSION_OVERWORLD, 25},
+		{"Nether", 0, 0, 512, DIMENSION_THE_NETHER, 25},
+		{"OffCenter", 32, 0, 64, DIMENSION_OVERWORLD, 18.75},
+		{"PartialChunks", 8, 8, 16, DIMENSION_OVERWORLD, 0},
+		{"NoArea", 0, 0, 0, DIMENSION_OVERWORLD, 0},
+	}
+
+	for _, tCase := range tMatrix {
+		t.Run(tCase.Name, func(t *testing.T) {
+			assert.Equal(t, tCase.Result, borderCoverage(tCase.CenterX, tCase.CenterZ, tCase.Size, tCase.Dimension, generated))
+		})
+	}
+}
+
+func TestChunkCollector(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	path := newTestWorld(t, "1.20")
+	require.NoError(os.MkdirAll(path+REGION_DIR, 0755))
+	writeTestRegion(t, path+REGION_DIR+"/r.0.0.mca", []testChunk{
+		{X: 0, Z: 0, Data: map[string]any{"Status": "minecraft:full", "InhabitedTime": int64(100)}},
+	})
+
+	regions, err := NewRegionScanner(path, nil)
+	require.NoError(err, "Should create region scanner")
+	c := NewChunkCollector(regions, "test-instance")
+
+	reg := prometheus.NewPedanticRegistry()
+	require.NoError(reg.Register(c), "Should register collector")
+
+	families, err := reg.Gather()
+	require.NoError(err, "Collected metrics should match the description")
+
+	names := make([]string, 0, len(families))
+	for _, family := range families {
+		names = append(names, family.GetName())
+	}
+	assert.ElementsMatch([]string{
+		"minecraft_world_chunk_status",
+		"minecraft_world_pregeneration_coverage_percent",
+		"minecraft_world_invalid_region_files",
+		"minecraft_world_corrupted_chunks",
+		"minecraft_world_oversized_chunks",
+		"minecraft_world_inhabited_time_ticks",
+	}, names)
+}
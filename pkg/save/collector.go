@@ -1,7 +1,12 @@
 package save
 
 import (
+	"errors"
+	"fmt"
 	"log/slog"
+	"runtime"
+	"slices"
+	"sync"
 	"time"
 
 	"github.com/heathcliff26/minecraft-exporter/pkg/rcon"
@@ -14,20 +19,45 @@ type SaveCollector struct {
 	uuidCache     *uuid.UUIDCache
 	ReduceMetrics bool
 	Instance      string
+	// Items that are counted in the inventory and ender chest of players
+	Items []string
+	// Namespaces of unmapped stat categories to export, exports all when empty
+	StatNamespaces []string
+	// Export the completion of every single advancement per player
+	AdvancementDetails bool
+	// Number of players that are loaded in parallel, defaults to the number of CPUs.
+	// Limits the player files that are decoded at the same time, the parsed data is kept in the player cache.
+	Workers int
 
 	RCON *rcon.RCONClient
+
+	loadErrors     map[playerLoadError]uint64
+	loadErrorsLock sync.Mutex
+}
+
+// Labels of the load error counter, the uuid is used as the name of the player might be unknown
+type playerLoadError struct {
+	uuid, reason string
 }
 
 var (
 	commonVariableLabels = []string{"instance", "player"}
+	worldVariableLabels  = []string{"instance", "dimension"}
+	levelVariableLabels  = []string{"instance"}
 
 	mcStatBlocksMinedReducedDesc    = prometheus.NewDesc("minecraft_stat_blocks_mined", "Blocks a player mined", commonVariableLabels, nil)
 	mcStatBlocksPickedUpReducedDesc = prometheus.NewDesc("minecraft_stat_blocks_picked_up", "Blocks a player picked up", commonVariableLabels, nil)
 	mcStatBlocksCraftedReducedDesc  = prometheus.NewDesc("minecraft_stat_blocks_crafted", "Items a player crafted", commonVariableLabels, nil)
+	mcStatItemsUsedReducedDesc      = prometheus.NewDesc("minecraft_stat_items_used", "Items a player used", commonVariableLabels, nil)
+	mcStatItemsBrokenReducedDesc    = prometheus.NewDesc("minecraft_stat_items_broken", "Items a player used up until they broke", commonVariableLabels, nil)
+	mcStatItemsDroppedReducedDesc   = prometheus.NewDesc("minecraft_stat_items_dropped", "Items a player dropped", commonVariableLabels, nil)
 
 	mcStatBlocksMinedDesc    = prometheus.NewDesc("minecraft_stat_blocks_mined", "Blocks a player mined", append(commonVariableLabels, "block"), nil)
 	mcStatBlocksPickedUpDesc = prometheus.NewDesc("minecraft_stat_blocks_picked_up", "Blocks a player picked up", append(commonVariableLabels, "block"), nil)
 	mcStatBlocksCraftedDesc  = prometheus.NewDesc("minecraft_stat_blocks_crafted", "Items a player crafted", append(commonVariableLabels, "block"), nil)
+	mcStatItemsUsedDesc      = prometheus.NewDesc("minecraft_stat_items_used", "Items a player used", append(commonVariableLabels, "item"), nil)
+	mcStatItemsBrokenDesc    = prometheus.NewDesc("minecraft_stat_items_broken", "Items a player used up until they broke", append(commonVariableLabels, "item"), nil)
+	mcStatItemsDroppedDesc   = prometheus.NewDesc("minecraft_stat_items_dropped", "Items a player dropped", append(commonVariableLabels, "item"), nil)
 
 	mcStatDeathsDesc            = prometheus.NewDesc("minecraft_stat_deaths", "How often a player died. Cause \"minecraft:deaths\" is used for total deaths", append(commonVariableLabels, "cause"), nil)
 	mcStatJumpsDesc             = prometheus.NewDesc("minecraft_stat_jumps", "How often a player has jumped", commonVariableLabels, nil)
@@ -41,10 +71,40 @@ var (
 	mcStatDamageTakenDesc       = prometheus.NewDesc("minecraft_stat_damage_taken", "Damage taken by player", commonVariableLabels, nil)
 	mcStatDamageDealtDesc       = prometheus.NewDesc("minecraft_stat_damage_dealt", "Damage dealt by player", commonVariableLabels, nil)
 	mcStatPlaytimeDesc          = prometheus.NewDesc("minecraft_stat_playtime", "Time in minutes a player was online", commonVariableLabels, nil)
-	mcStatAdvancementsDesc      = prometheus.NewDesc("minecraft_stat_advancements", "Number of completed advancements of a player", commonVariableLabels, nil)
+	mcStatAdvancementsDesc      = prometheus.NewDesc("minecraft_stat_advancements", "Number of completed advancements of a player, excluding recipe unlocks", commonVariableLabels, nil)
+	mcStatRecipesUnlockedDesc   = prometheus.NewDesc("minecraft_stat_recipes_unlocked", "Number of recipes a player has unlocked", commonVariableLabels, nil)
 	mcStatSleptDesc             = prometheus.NewDesc("minecraft_stat_slept", "Times a player slept in a bed", commonVariableLabels, nil)
 	mcStatUsedCraftingTableDesc = prometheus.NewDesc("minecraft_stat_used_crafting_table", "Times a player used a crafting table", commonVariableLabels, nil)
 	mcStatCustomDesc            = prometheus.NewDesc("minecraft_stat_custom", "Custom minecraft stat", append(commonVariableLabels, "stat"), nil)
+	mcStatCategoryDesc          = prometheus.NewDesc("minecraft_stat_category", "Stat of a category that is not mapped to a dedicated metric, e.g. added by mods", append(commonVariableLabels, "category", "key"), nil)
+
+	mcPlayerPositionDesc                 = prometheus.NewDesc("minecraft_player_position", "Position of the player", append(commonVariableLabels, "dimension", "axis"), nil)
+	mcPlayerRotationDesc                 = prometheus.NewDesc("minecraft_player_rotation", "Rotation of the player in degrees", append(commonVariableLabels, "dimension", "axis"), nil)
+	mcPlayerSpawnPositionDesc            = prometheus.NewDesc("minecraft_player_spawn_position", "Position of the respawn point of the player, e.g. the last bed used", append(commonVariableLabels, "dimension", "axis"), nil)
+	mcPlayerLastDeathPositionDesc        = prometheus.NewDesc("minecraft_player_last_death_position", "Position where the player died the last time", append(commonVariableLabels, "dimension", "axis"), nil)
+	mcPlayerLastAdvancementDesc          = prometheus.NewDesc("minecraft_player_last_advancement_timestamp_seconds", "Time the player completed the most recent advancement as unix timestamp", commonVariableLabels, nil)
+	mcPlayerAdvancementDoneDesc          = prometheus.NewDesc("minecraft_player_advancement_done", "Indicates if the player completed the advancement", append(commonVariableLabels, "advancement"), nil)
+	mcPlayerAdvancementCompletedTimeDesc = prometheus.NewDesc("minecraft_player_advancement_completed_timestamp_seconds", "Time the player completed the advancement as unix timestamp", append(commonVariableLabels, "advancement"), nil)
+	mcPlayerAdvancementTabDoneDesc       = prometheus.NewDesc("minecraft_player_advancement_tab_completed", "Number of vanilla advancements of the tab the player completed", append(commonVariableLabels, "tab"), nil)
+	mcPlayerAdvancementTabPercentDesc    = prometheus.NewDesc("minecraft_player_advancement_tab_completion_percent", "Percentage of the vanilla advancements of the tab the player completed", append(commonVariableLabels, "tab"), nil)
+	mcPlayerAdvancementCriteriaDesc      = prometheus.NewDesc("minecraft_player_advancement_criteria", "Number of criteria the player met for vanilla advancements requiring multiple criteria, e.g. biomes visited for adventuring_time", append(commonVariableLabels, "advancement"), nil)
+	mcPlayerGameModeDesc                 = prometheus.NewDesc("minecraft_player_game_mode", "Game mode of the player (0 = survival, 1 = creative, 2 = adventure, 3 = spectator)", commonVariableLabels, nil)
+	mcPlayerAirDesc                      = prometheus.NewDesc("minecraft_player_air_ticks", "Ticks of air the player has left before drowning", commonVariableLabels, nil)
+	mcPlayerFireDesc                     = prometheus.NewDesc("minecraft_player_fire_ticks", "Ticks until the fire on the player is extinguished, negative when not burning", commonVariableLabels, nil)
+	mcPlayerAbsorptionDesc               = prometheus.NewDesc("minecraft_player_absorption", "Absorption health the player currently has", commonVariableLabels, nil)
+	mcPlayerFoodSaturationDesc           = prometheus.NewDesc("minecraft_player_food_saturation", "Food saturation level of the player", commonVariableLabels, nil)
+	mcPlayerXPProgressDesc               = prometheus.NewDesc("minecraft_player_xp_progress", "Progress towards the next level, between 0 and 1", commonVariableLabels, nil)
+	mcPlayerEffectAmplifierDesc          = prometheus.NewDesc("minecraft_player_effect_amplifier", "Amplifier of an active status effect of the player, 0 is level I", append(commonVariableLabels, "effect"), nil)
+	mcPlayerEffectDurationDesc           = prometheus.NewDesc("minecraft_player_effect_duration_ticks", "Remaining duration of an active status effect of the player, -1 for infinite", append(commonVariableLabels, "effect"), nil)
+	mcPlayerAttributeBaseDesc            = prometheus.NewDesc("minecraft_player_attribute_base", "Base value of an attribute of the player", append(commonVariableLabels, "attribute"), nil)
+	mcPlayerAttributeModifierDesc        = prometheus.NewDesc("minecraft_player_attribute_modifier", "Sum of the amounts of the modifiers of an attribute of the player", append(commonVariableLabels, "attribute", "modifier", "operation"), nil)
+	mcPlayerItemsDesc                    = prometheus.NewDesc("minecraft_player_items", "Number of items a player has in the inventory or ender chest", append(commonVariableLabels, "item", "inventory"), nil)
+
+	exporterPlayerCacheHitsDesc   = prometheus.NewDesc("minecraft_exporter_player_cache_hits_total", "Number of times the data of a player was served from the cache", levelVariableLabels, nil)
+	exporterPlayerCacheMissesDesc = prometheus.NewDesc("minecraft_exporter_player_cache_misses_total", "Number of times the data of a player had to be parsed, because it was not cached or a file changed", levelVariableLabels, nil)
+	exporterPlayerLoadErrorsDesc  = prometheus.NewDesc("minecraft_exporter_player_load_errors_total", "Number of times the data of a player could not be loaded by uuid of the player", []string{"instance", "uuid", "reason"}, nil)
+
+	mcSaveUpDesc = prometheus.NewDesc("minecraft_save_up", "Indicates if the list of players could be read from the save", levelVariableLabels, nil)
 )
 
 // Create new instance of collector, returns error if an world directory is not provided
@@ -64,6 +124,7 @@ func NewSaveCollector(path, instance string, reduceMetrics bool) (*SaveCollector
 		uuidCache:     uuid.NewUUIDCache(time.Hour * 12),
 		ReduceMetrics: reduceMetrics,
 		Instance:      instance,
+		loadErrors:    make(map[playerLoadError]uint64),
 	}, nil
 }
 
@@ -73,10 +134,16 @@ func (c *SaveCollector) Describe(ch chan<- *prometheus.Desc) {
 		ch <- mcStatBlocksMinedReducedDesc
 		ch <- mcStatBlocksPickedUpReducedDesc
 		ch <- mcStatBlocksCraftedReducedDesc
+		ch <- mcStatItemsUsedReducedDesc
+		ch <- mcStatItemsBrokenReducedDesc
+		ch <- mcStatItemsDroppedReducedDesc
 	} else {
 		ch <- mcStatBlocksMinedDesc
 		ch <- mcStatBlocksPickedUpDesc
 		ch <- mcStatBlocksCraftedDesc
+		ch <- mcStatItemsUsedDesc
+		ch <- mcStatItemsBrokenDesc
+		ch <- mcStatItemsDroppedDesc
 	}
 
 	ch <- mcStatDeathsDesc
@@ -92,9 +159,41 @@ func (c *SaveCollector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- mcStatDamageDealtDesc
 	ch <- mcStatPlaytimeDesc
 	ch <- mcStatAdvancementsDesc
+	ch <- mcStatRecipesUnlockedDesc
 	ch <- mcStatSleptDesc
 	ch <- mcStatUsedCraftingTableDesc
 	ch <- mcStatCustomDesc
+	ch <- mcStatCategoryDesc
+
+	ch <- mcPlayerPositionDesc
+	ch <- mcPlayerRotationDesc
+	ch <- mcPlayerSpawnPositionDesc
+	ch <- mcPlayerLastDeathPositionDesc
+	ch <- mcPlayerLastAdvancementDesc
+	if c.AdvancementDetails {
+		ch <- mcPlayerAdvancementDoneDesc
+		ch <- mcPlayerAdvancementCompletedTimeDesc
+	}
+	ch <- mcPlayerAdvancementTabDoneDesc
+	ch <- mcPlayerAdvancementTabPercentDesc
+	ch <- mcPlayerAdvancementCriteriaDesc
+	ch <- mcPlayerGameModeDesc
+	ch <- mcPlayerAirDesc
+	ch <- mcPlayerFireDesc
+	ch <- mcPlayerAbsorptionDesc
+	ch <- mcPlayerFoodSaturationDesc
+	ch <- mcPlayerXPProgressDesc
+	ch <- mcPlayerEffectAmplifierDesc
+	ch <- mcPlayerEffectDurationDesc
+	ch <- mcPlayerAttributeBaseDesc
+	ch <- mcPlayerAttributeModifierDesc
+	ch <- mcPlayerItemsDesc
+
+	ch <- exporterPlayerCacheHitsDesc
+	ch <- exporterPlayerCacheMissesDesc
+	ch <- exporterPlayerLoadErrorsDesc
+
+	ch <- mcSaveUpDesc
 }
 
 // Implements the Collect function for prometheus.Collector
@@ -104,77 +203,298 @@ func (c *SaveCollector) Collect(ch chan<- prometheus.Metric) {
 	players, err := c.save.GetPlayers()
 	if err != nil {
 		slog.Error("Failed to get list of players", "err", err)
+		ch <- prometheus.MustNewConstMetric(mcSaveUpDesc, prometheus.GaugeValue, 0, c.Instance)
+		ch <- prometheus.NewInvalidMetric(mcSaveUpDesc, err)
+		c.collectLoadErrors(ch)
 		return
 	}
 
+	workers := c.Workers
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+
+	// Stream the players to a bounded number of workers, so only as many player files as there are workers are decoded at the same time.
+	// The parsed data stays in the player cache, which is limited to the configured number of players.
+	// Name lookups are serialized by the uuid cache, to avoid being rate limited by mojang.
+	// Players that fail to load are skipped, the failure is returned as invalid metric to show up in the scrape.
+	items := namespacedIDs(c.Items)
+	jobs := make(chan string)
+	var wg sync.WaitGroup
+	for range min(workers, len(players)) {
+		wg.Go(func() {
+			for player := range jobs {
+				err := c.collectPlayer(ch, player, items)
+				if err == nil {
+					continue
+				}
+				reason := PLAYER_LOAD_ERROR_UNKNOWN
+				var loadErr *ErrLoadPlayer
+				if errors.As(err, &loadErr) {
+					reason = loadErr.Reason
+				}
+				c.addLoadError(player, reason)
+				ch <- prometheus.NewInvalidMetric(exporterPlayerLoadErrorsDesc, fmt.Errorf("player %s: %w", player, err))
+			}
+		})
+	}
 	for _, player := range players {
-		name, err := c.uuidCache.GetNameFromUUID(player)
-		if err != nil {
-			slog.Error("Failed to fetch name from uuid", "err", err, "player", player)
-			return
-		}
+		jobs <- player
+	}
+	close(jobs)
+	wg.Wait()
+
+	// Only remove players that are no longer part of the save, overlapping collections work with the same list
+	c.save.playerCache.prune(players)
+	c.pruneLoadErrors(players)
+
+	hits, misses := c.save.PlayerCacheStats()
+	ch <- prometheus.MustNewConstMetric(exporterPlayerCacheHitsDesc, prometheus.CounterValue, float64(hits), c.Instance)
+	ch <- prometheus.MustNewConstMetric(exporterPlayerCacheMissesDesc, prometheus.CounterValue, float64(misses), c.Instance)
+	c.collectLoadErrors(ch)
+	ch <- prometheus.MustNewConstMetric(mcSaveUpDesc, prometheus.GaugeValue, 1, c.Instance)
+
+	c.updateRCONMinecraftVersion()
+
+	slog.Debug("Finished collection of minecraft metrics from savedata")
+}
+
+// Count a failure to load the data of a player
+func (c *SaveCollector) addLoadError(player, reason string) {
+	c.loadErrorsLock.Lock()
+	defer c.loadErrorsLock.Unlock()
 
-		d, err := c.save.LoadPlayerData(player)
-		if err != nil {
-			slog.Error("Failed to load data for player", "err", err, "player", player)
-			return
+	if c.loadErrors == nil {
+		c.loadErrors = make(map[playerLoadError]uint64)
+	}
+	c.loadErrors[playerLoadError{uuid: player, reason: reason}]++
+}
+
+// Remove the load errors of players that are no longer part of the save
+func (c *SaveCollector) pruneLoadErrors(players []string) {
+	c.loadErrorsLock.Lock()
+	defer c.loadErrorsLock.Unlock()
+
+	keep := make(map[string]bool, len(players))
+	for _, player := range players {
+		keep[player] = true
+	}
+	for key := range c.loadErrors {
+		if !keep[key.uuid] {
+			delete(c.loadErrors, key)
 		}
+	}
+}
+
+// Send the number of failures to load player data per player and reason
+func (c *SaveCollector) collectLoadErrors(ch chan<- prometheus.Metric) {
+	c.loadErrorsLock.Lock()
+	defer c.loadErrorsLock.Unlock()
 
-		commonLabels := []string{c.Instance, name}
+	for key, count := range c.loadErrors {
+		ch <- prometheus.MustNewConstMetric(exporterPlayerLoadErrorsDesc, prometheus.CounterValue, float64(count), c.Instance, key.uuid, key.reason)
+	}
+}
 
-		if c.ReduceMetrics {
-			ch <- prometheus.MustNewConstMetric(mcStatBlocksMinedReducedDesc, prometheus.CounterValue, float64(countTotal(d.Stats.Mined)), commonLabels...)
-			ch <- prometheus.MustNewConstMetric(mcStatBlocksPickedUpReducedDesc, prometheus.CounterValue, float64(countTotal(d.Stats.PickedUp)), commonLabels...)
-			ch <- prometheus.MustNewConstMetric(mcStatBlocksCraftedReducedDesc, prometheus.CounterValue, float64(countTotal(d.Stats.CraftedItems)), commonLabels...)
-		} else {
-			mapToMetrics(ch, mcStatBlocksMinedDesc, d.Stats.Mined, commonLabels)
-			mapToMetrics(ch, mcStatBlocksPickedUpDesc, d.Stats.PickedUp, commonLabels)
-			mapToMetrics(ch, mcStatBlocksCraftedDesc, d.Stats.CraftedItems, commonLabels)
+// Collect the metrics of a single player.
+// Safe to be called concurrently for different players.
+func (c *SaveCollector) collectPlayer(ch chan<- prometheus.Metric, player string, items []string) error {
+	name, err := c.uuidCache.GetNameFromUUID(player)
+	if err != nil {
+		slog.Error("Failed to fetch name from uuid", "err", err, "player", player)
+		return NewErrLoadPlayer(PLAYER_LOAD_ERROR_UUID_LOOKUP, err)
+	}
+
+	d, err := c.save.LoadPlayerData(player)
+	if err != nil {
+		slog.Error("Failed to load data for player", "err", err, "player", player)
+		return err
+	}
+
+	commonLabels := []string{c.Instance, name}
+
+	if c.ReduceMetrics {
+		ch <- prometheus.MustNewConstMetric(mcStatBlocksMinedReducedDesc, prometheus.CounterValue, float64(countTotal(d.Stats.Mined)), commonLabels...)
+		ch <- prometheus.MustNewConstMetric(mcStatBlocksPickedUpReducedDesc, prometheus.CounterValue, float64(countTotal(d.Stats.PickedUp)), commonLabels...)
+		ch <- prometheus.MustNewConstMetric(mcStatBlocksCraftedReducedDesc, prometheus.CounterValue, float64(countTotal(d.Stats.CraftedItems)), commonLabels...)
+		ch <- prometheus.MustNewConstMetric(mcStatItemsUsedReducedDesc, prometheus.CounterValue, float64(countTotal(d.Stats.Used)), commonLabels...)
+		ch <- prometheus.MustNewConstMetric(mcStatItemsBrokenReducedDesc, prometheus.CounterValue, float64(countTotal(d.Stats.Broken)), commonLabels...)
+		ch <- prometheus.MustNewConstMetric(mcStatItemsDroppedReducedDesc, prometheus.CounterValue, float64(countTotal(d.Stats.Dropped)), commonLabels...)
+	} else {
+		mapToMetrics(ch, mcStatBlocksMinedDesc, d.Stats.Mined, commonLabels)
+		mapToMetrics(ch, mcStatBlocksPickedUpDesc, d.Stats.PickedUp, commonLabels)
+		mapToMetrics(ch, mcStatBlocksCraftedDesc, d.Stats.CraftedItems, commonLabels)
+		mapToMetrics(ch, mcStatItemsUsedDesc, d.Stats.Used, commonLabels)
+		mapToMetrics(ch, mcStatItemsBrokenDesc, d.Stats.Broken, commonLabels)
+		mapToMetrics(ch, mcStatItemsDroppedDesc, d.Stats.Dropped, commonLabels)
+	}
+
+	for key, value := range d.Stats.KilledBy {
+		ch <- prometheus.MustNewConstMetric(mcStatDeathsDesc, prometheus.CounterValue, float64(value), append(commonLabels, key)...)
+	}
+	ch <- prometheus.MustNewConstMetric(mcStatDeathsDesc, prometheus.CounterValue, float64(d.Stats.Custom.Deaths), append(commonLabels, "minecraft:deaths")...)
+
+	ch <- prometheus.MustNewConstMetric(mcStatJumpsDesc, prometheus.CounterValue, float64(d.Stats.Custom.Jump), commonLabels...)
+	ch <- prometheus.MustNewConstMetric(mcStatCMTraveledDesc, prometheus.CounterValue, float64(d.Stats.Custom.Walk), append(commonLabels, "walking")...)
+	ch <- prometheus.MustNewConstMetric(mcStatCMTraveledDesc, prometheus.CounterValue, float64(d.Stats.Custom.Swim), append(commonLabels, "swimming")...)
+	ch <- prometheus.MustNewConstMetric(mcStatCMTraveledDesc, prometheus.CounterValue, float64(d.Stats.Custom.Sprint), append(commonLabels, "sprinting")...)
+	ch <- prometheus.MustNewConstMetric(mcStatCMTraveledDesc, prometheus.CounterValue, float64(d.Stats.Custom.Dive), append(commonLabels, "diving")...)
+	ch <- prometheus.MustNewConstMetric(mcStatCMTraveledDesc, prometheus.CounterValue, float64(d.Stats.Custom.Fall), append(commonLabels, "falling")...)
+	ch <- prometheus.MustNewConstMetric(mcStatCMTraveledDesc, prometheus.CounterValue, float64(d.Stats.Custom.Fly), append(commonLabels, "flying")...)
+	ch <- prometheus.MustNewConstMetric(mcStatCMTraveledDesc, prometheus.CounterValue, float64(d.Stats.Custom.Boat), append(commonLabels, "boat")...)
+	ch <- prometheus.MustNewConstMetric(mcStatCMTraveledDesc, prometheus.CounterValue, float64(d.Stats.Custom.Horse), append(commonLabels, "Horse")...)
+	ch <- prometheus.MustNewConstMetric(mcStatCMTraveledDesc, prometheus.CounterValue, float64(d.Stats.Custom.Climb), append(commonLabels, "climbing")...)
+
+	ch <- prometheus.MustNewConstMetric(mcStatXPTotalDesc, prometheus.CounterValue, float64(d.PlayerData.XPTotal), commonLabels...)
+	ch <- prometheus.MustNewConstMetric(mcStatCurrentLevelDesc, prometheus.CounterValue, float64(d.PlayerData.XPLevel), commonLabels...)
+	ch <- prometheus.MustNewConstMetric(mcStatFoodLevelDesc, prometheus.CounterValue, float64(d.PlayerData.FoodLevel), commonLabels...)
+	ch <- prometheus.MustNewConstMetric(mcStatHealthDesc, prometheus.CounterValue, float64(d.PlayerData.Health), commonLabels...)
+	ch <- prometheus.MustNewConstMetric(mcStatScoreDesc, prometheus.CounterValue, float64(d.PlayerData.Score), commonLabels...)
+
+	for key, value := range d.Stats.Killed {
+		ch <- prometheus.MustNewConstMetric(mcStatEntitiesKilledDesc, prometheus.CounterValue, float64(value), append(commonLabels, key)...)
+	}
+
+	ch <- prometheus.MustNewConstMetric(mcStatDamageTakenDesc, prometheus.CounterValue, float64(d.Stats.Custom.DamageTaken), commonLabels...)
+	ch <- prometheus.MustNewConstMetric(mcStatDamageDealtDesc, prometheus.CounterValue, float64(d.Stats.Custom.DamageDealt), commonLabels...)
+	ch <- prometheus.MustNewConstMetric(mcStatPlaytimeDesc, prometheus.CounterValue, float64(d.Stats.Custom.Playtime), commonLabels...)
+	ch <- prometheus.MustNewConstMetric(mcStatSleptDesc, prometheus.CounterValue, float64(d.Stats.Custom.Sleep), commonLabels...)
+	ch <- prometheus.MustNewConstMetric(mcStatUsedCraftingTableDesc, prometheus.CounterValue, float64(d.Stats.Custom.Crafted), commonLabels...)
+
+	advancements := countAdvancements(d.Advancements)
+	ch <- prometheus.MustNewConstMetric(mcStatAdvancementsDesc, prometheus.CounterValue, float64(advancements), commonLabels...)
+	ch <- prometheus.MustNewConstMetric(mcStatRecipesUnlockedDesc, prometheus.CounterValue, float64(countRecipes(d.Advancements)), commonLabels...)
+	collectAdvancements(ch, c.AdvancementDetails, d.Advancements, commonLabels)
+	collectAdvancementProgress(ch, c.save.Version.Id, d.Advancements, commonLabels)
+
+	for key, value := range d.Stats.Custom.Custom {
+		ch <- prometheus.MustNewConstMetric(mcStatCustomDesc, prometheus.CounterValue, float64(value), append(commonLabels, key)...)
+	}
+
+	collectStatCategories(ch, c.StatNamespaces, d.Stats.Other, commonLabels)
+
+	collectPlayerPosition(ch, d.PlayerData, commonLabels)
+	collectPlayerStatus(ch, d.PlayerData, commonLabels)
+	collectPlayerItems(ch, items, d.PlayerData, commonLabels)
+
+	return nil
+}
+
+// Collect the time of the last completed advancement and optionally the completion of every advancement.
+// Recipe unlocks are skipped, as they are counted separately.
+func collectAdvancements(ch chan<- prometheus.Metric, details bool, advancements map[string]Advancement, commonLabels []string) {
+	if last, ok := lastAdvancementTime(advancements); ok {
+		ch <- prometheus.MustNewConstMetric(mcPlayerLastAdvancementDesc, prometheus.GaugeValue, float64(last.Unix()), commonLabels...)
+	}
+
+	if !details {
+		return
+	}
+	for key, advancement := range advancements {
+		if isRecipe(key) {
+			continue
 		}
+		ch <- prometheus.MustNewConstMetric(mcPlayerAdvancementDoneDesc, prometheus.GaugeValue, boolToFloat(advancement.Done), append(commonLabels, key)...)
+		if completed, ok := advancement.CompletedAt(); ok {
+			ch <- prometheus.MustNewConstMetric(mcPlayerAdvancementCompletedTimeDesc, prometheus.GaugeValue, float64(completed.Unix()), append(commonLabels, key)...)
+		}
+	}
+}
 
-		for key, value := range d.Stats.KilledBy {
-			ch <- prometheus.MustNewConstMetric(mcStatDeathsDesc, prometheus.CounterValue, float64(value), append(commonLabels, key)...)
+// Collect the progress of the player for the vanilla advancements that exist in the given data version
+func collectAdvancementProgress(ch chan<- prometheus.Metric, dataVersion int, advancements map[string]Advancement, commonLabels []string) {
+	for tab, progress := range advancementTabProgress(dataVersion, advancements) {
+		ch <- prometheus.MustNewConstMetric(mcPlayerAdvancementTabDoneDesc, prometheus.GaugeValue, float64(progress.Done), append(commonLabels, tab)...)
+		ch <- prometheus.MustNewConstMetric(mcPlayerAdvancementTabPercentDesc, prometheus.GaugeValue, progress.Percent(), append(commonLabels, tab)...)
+	}
+	for id, criteria := range advancementCriteriaProgress(dataVersion, advancements) {
+		ch <- prometheus.MustNewConstMetric(mcPlayerAdvancementCriteriaDesc, prometheus.GaugeValue, float64(criteria), append(commonLabels, id)...)
+	}
+}
+
+// Collect the stats of all categories that are not mapped to a dedicated metric.
+// When namespaces are given, only categories from these namespaces are collected.
+func collectStatCategories(ch chan<- prometheus.Metric, namespaces []string, categories map[string]map[string]int, commonLabels []string) {
+	for category, stats := range categories {
+		if len(namespaces) > 0 && !slices.Contains(namespaces, statNamespace(category)) {
+			continue
 		}
-		ch <- prometheus.MustNewConstMetric(mcStatDeathsDesc, prometheus.CounterValue, float64(d.Stats.Custom.Deaths), append(commonLabels, "minecraft:deaths")...)
-
-		ch <- prometheus.MustNewConstMetric(mcStatJumpsDesc, prometheus.CounterValue, float64(d.Stats.Custom.Jump), commonLabels...)
-		ch <- prometheus.MustNewConstMetric(mcStatCMTraveledDesc, prometheus.CounterValue, float64(d.Stats.Custom.Walk), append(commonLabels, "walking")...)
-		ch <- prometheus.MustNewConstMetric(mcStatCMTraveledDesc, prometheus.CounterValue, float64(d.Stats.Custom.Swim), append(commonLabels, "swimming")...)
-		ch <- prometheus.MustNewConstMetric(mcStatCMTraveledDesc, prometheus.CounterValue, float64(d.Stats.Custom.Sprint), append(commonLabels, "sprinting")...)
-		ch <- prometheus.MustNewConstMetric(mcStatCMTraveledDesc, prometheus.CounterValue, float64(d.Stats.Custom.Dive), append(commonLabels, "diving")...)
-		ch <- prometheus.MustNewConstMetric(mcStatCMTraveledDesc, prometheus.CounterValue, float64(d.Stats.Custom.Fall), append(commonLabels, "falling")...)
-		ch <- prometheus.MustNewConstMetric(mcStatCMTraveledDesc, prometheus.CounterValue, float64(d.Stats.Custom.Fly), append(commonLabels, "flying")...)
-		ch <- prometheus.MustNewConstMetric(mcStatCMTraveledDesc, prometheus.CounterValue, float64(d.Stats.Custom.Boat), append(commonLabels, "boat")...)
-		ch <- prometheus.MustNewConstMetric(mcStatCMTraveledDesc, prometheus.CounterValue, float64(d.Stats.Custom.Horse), append(commonLabels, "Horse")...)
-		ch <- prometheus.MustNewConstMetric(mcStatCMTraveledDesc, prometheus.CounterValue, float64(d.Stats.Custom.Climb), append(commonLabels, "climbing")...)
-
-		ch <- prometheus.MustNewConstMetric(mcStatXPTotalDesc, prometheus.CounterValue, float64(d.PlayerData.XPTotal), commonLabels...)
-		ch <- prometheus.MustNewConstMetric(mcStatCurrentLevelDesc, prometheus.CounterValue, float64(d.PlayerData.XPLevel), commonLabels...)
-		ch <- prometheus.MustNewConstMetric(mcStatFoodLevelDesc, prometheus.CounterValue, float64(d.PlayerData.FoodLevel), commonLabels...)
-		ch <- prometheus.MustNewConstMetric(mcStatHealthDesc, prometheus.CounterValue, float64(d.PlayerData.Health), commonLabels...)
-		ch <- prometheus.MustNewConstMetric(mcStatScoreDesc, prometheus.CounterValue, float64(d.PlayerData.Score), commonLabels...)
-
-		for key, value := range d.Stats.Killed {
-			ch <- prometheus.MustNewConstMetric(mcStatEntitiesKilledDesc, prometheus.CounterValue, float64(value), append(commonLabels, key)...)
+		for key, value := range stats {
+			ch <- prometheus.MustNewConstMetric(mcStatCategoryDesc, prometheus.CounterValue, float64(value), append(commonLabels, category, key)...)
 		}
+	}
+}
+
+// Collect the position of the player, as well as the respawn point and last death location
+func collectPlayerPosition(ch chan<- prometheus.Metric, data MinecraftPlayerData, commonLabels []string) {
+	dimension := data.GetDimension()
+	if pos, ok := data.GetPosition(); ok {
+		ch <- prometheus.MustNewConstMetric(mcPlayerPositionDesc, prometheus.GaugeValue, pos[0], append(commonLabels, dimension, "x")...)
+		ch <- prometheus.MustNewConstMetric(mcPlayerPositionDesc, prometheus.GaugeValue, pos[1], append(commonLabels, dimension, "y")...)
+		ch <- prometheus.MustNewConstMetric(mcPlayerPositionDesc, prometheus.GaugeValue, pos[2], append(commonLabels, dimension, "z")...)
+	}
+	if len(data.Rotation) == 2 {
+		ch <- prometheus.MustNewConstMetric(mcPlayerRotationDesc, prometheus.GaugeValue, float64(data.Rotation[0]), append(commonLabels, dimension, "yaw")...)
+		ch <- prometheus.MustNewConstMetric(mcPlayerRotationDesc, prometheus.GaugeValue, float64(data.Rotation[1]), append(commonLabels, dimension, "pitch")...)
+	}
+
+	if spawn, ok := data.GetSpawn(); ok {
+		globalPosToMetrics(ch, mcPlayerSpawnPositionDesc, spawn, commonLabels)
+	}
+	if data.LastDeathLocation != nil && len(data.LastDeathLocation.Pos) == 3 {
+		globalPosToMetrics(ch, mcPlayerLastDeathPositionDesc, *data.LastDeathLocation, commonLabels)
+	}
+}
 
-		ch <- prometheus.MustNewConstMetric(mcStatDamageTakenDesc, prometheus.CounterValue, float64(d.Stats.Custom.DamageTaken), commonLabels...)
-		ch <- prometheus.MustNewConstMetric(mcStatDamageDealtDesc, prometheus.CounterValue, float64(d.Stats.Custom.DamageDealt), commonLabels...)
-		ch <- prometheus.MustNewConstMetric(mcStatPlaytimeDesc, prometheus.CounterValue, float64(d.Stats.Custom.Playtime), commonLabels...)
-		ch <- prometheus.MustNewConstMetric(mcStatSleptDesc, prometheus.CounterValue, float64(d.Stats.Custom.Sleep), commonLabels...)
-		ch <- prometheus.MustNewConstMetric(mcStatUsedCraftingTableDesc, prometheus.CounterValue, float64(d.Stats.Custom.Crafted), commonLabels...)
+// Collect the game mode, status effects and attributes of the player
+func collectPlayerStatus(ch chan<- prometheus.Metric, data MinecraftPlayerData, commonLabels []string) {
+	ch <- prometheus.MustNewConstMetric(mcPlayerGameModeDesc, prometheus.GaugeValue, float64(data.GameMode), commonLabels...)
+	ch <- prometheus.MustNewConstMetric(mcPlayerAirDesc, prometheus.GaugeValue, float64(data.Air), commonLabels...)
+	ch <- prometheus.MustNewConstMetric(mcPlayerFireDesc, prometheus.GaugeValue, float64(data.Fire), commonLabels...)
+	ch <- prometheus.MustNewConstMetric(mcPlayerAbsorptionDesc, prometheus.GaugeValue, data.AbsorptionAmount, commonLabels...)
+	ch <- prometheus.MustNewConstMetric(mcPlayerFoodSaturationDesc, prometheus.GaugeValue, data.FoodSaturation, commonLabels...)
+	ch <- prometheus.MustNewConstMetric(mcPlayerXPProgressDesc, prometheus.GaugeValue, data.XPProgress, commonLabels...)
 
-		advancements := countAdvancements(d.Advancements)
-		ch <- prometheus.MustNewConstMetric(mcStatAdvancementsDesc, prometheus.CounterValue, float64(advancements), commonLabels...)
+	for _, effect := range data.GetEffects() {
+		id := effect.GetID()
+		ch <- prometheus.MustNewConstMetric(mcPlayerEffectAmplifierDesc, prometheus.GaugeValue, float64(effect.Amplifier), append(commonLabels, id)...)
+		ch <- prometheus.MustNewConstMetric(mcPlayerEffectDurationDesc, prometheus.GaugeValue, float64(effect.Duration), append(commonLabels, id)...)
+	}
+
+	for _, attribute := range data.GetAttributes() {
+		id := attribute.GetID()
+		ch <- prometheus.MustNewConstMetric(mcPlayerAttributeBaseDesc, prometheus.GaugeValue, attribute.Base, append(commonLabels, id)...)
 
-		for key, value := range d.Stats.Custom.Custom {
-			ch <- prometheus.MustNewConstMetric(mcStatCustomDesc, prometheus.CounterValue, float64(value), append(commonLabels, key)...)
+		// Older versions identify modifiers by uuid, so the same name can appear multiple times
+		modifiers := make(map[[2]string]float64, len(attribute.Modifiers))
+		for _, modifier := range attribute.Modifiers {
+			modifiers[[2]string{modifier.GetID(), modifier.GetOperation()}] += modifier.Amount
+		}
+		for key, amount := range modifiers {
+			ch <- prometheus.MustNewConstMetric(mcPlayerAttributeModifierDesc, prometheus.GaugeValue, amount, append(commonLabels, id, key[0], key[1])...)
 		}
 	}
+}
 
-	c.updateRCONMinecraftVersion()
+// Collect the number of the given items in the inventory and ender chest of the player.
+// Items that the player does not have are reported as 0.
+// The items need to be namespaced and unique, see namespacedIDs.
+func collectPlayerItems(ch chan<- prometheus.Metric, items []string, data MinecraftPlayerData, commonLabels []string) {
+	if len(items) == 0 {
+		return
+	}
 
-	slog.Debug("Finished collection of minecraft metrics from savedata")
+	inventory := data.InventoryItems()
+	enderChest := data.EnderChestItems()
+	for _, id := range items {
+		ch <- prometheus.MustNewConstMetric(mcPlayerItemsDesc, prometheus.GaugeValue, float64(inventory[id]), append(commonLabels, id, "inventory")...)
+		ch <- prometheus.MustNewConstMetric(mcPlayerItemsDesc, prometheus.GaugeValue, float64(enderChest[id]), append(commonLabels, id, "ender_chest")...)
+	}
+}
+
+// Set the maximum number of players whose parsed data is cached, 0 for no limit
+func (c *SaveCollector) SetPlayerCacheSize(size int) {
+	c.save.SetPlayerCacheSize(size)
 }
 
 func (c *SaveCollector) SetRCONClient(rc *rcon.RCONClient) error {
@@ -1,10 +1,17 @@
 package save
 
 import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/heathcliff26/minecraft-exporter/pkg/rcon"
+	"github.com/heathcliff26/minecraft-exporter/pkg/uuid"
 	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -40,7 +47,15 @@ func TestCollectHasCommonLabels(t *testing.T) {
 
 	for metric := range ch {
 		desc := metric.Desc().String()
-		assert.Contains(desc, "variableLabels: {instance,player", "Metric description should contain the correct instance label")
+		assert.NotContains(desc, "fqName: \"minecraft_world_", "World metrics should be collected by the WorldCollector")
+		switch {
+		case strings.Contains(desc, "fqName: \"minecraft_exporter_player_load_errors_total\""):
+			assert.Contains(desc, "variableLabels: {instance,uuid,reason}", "Load errors should contain the uuid and reason labels")
+		case strings.Contains(desc, "fqName: \"minecraft_exporter_"), strings.Contains(desc, "fqName: \"minecraft_save_up\""):
+			assert.Contains(desc, "variableLabels: {instance}", "Exporter metrics should only contain the instance label")
+		default:
+			assert.Contains(desc, "variableLabels: {instance,player", "Metric description should contain the correct instance label")
+		}
 	}
 }
 
@@ -57,7 +72,7 @@ func TestCollectorDescribe(t *testing.T) {
 			c, err := NewSaveCollector("./testdata/1.20", "test-instance", reduceMetrics)
 			require.NoError(err, "Should create collector")
 
-			expectedDescCount := 19
+			expectedDescCount := 47
 
 			ch := make(chan *prometheus.Desc)
 			expectedDescs := make([]*prometheus.Desc, 0, expectedDescCount)
@@ -88,6 +103,296 @@ func TestCollectorDescribe(t *testing.T) {
 
 }
 
+func TestCollectWorkersAreDeterministic(t *testing.T) {
+	for _, version := range []string{"1.12", "1.20", "26"} {
+		t.Run(version, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			path := newTestWorld(t, version)
+			s, err := NewSave(path)
+			require.NoError(err, "Should create save")
+
+			// Add copies of the test player, so the workers have something to share
+			names := map[string]string{testUUID: "test-player"}
+			for i := range 15 {
+				player := fmt.Sprintf("00000000-0000-0000-0000-%012d", i)
+				require.NoError(copyFile(filepath.Join(s.statsDir, testUUID+".json"), filepath.Join(s.statsDir, player+".json")))
+				require.NoError(copyFile(filepath.Join(s.advancementsDir, testUUID+".json"), filepath.Join(s.advancementsDir, player+".json")))
+				require.NoError(copyFile(filepath.Join(s.playerDir, testUUID+".dat"), filepath.Join(s.playerDir, player+".dat")))
+				names[player] = fmt.Sprintf("player-%d", i)
+			}
+
+			var expected []string
+			for _, workers := range []int{1, 2, 8} {
+				c, err := NewSaveCollector(path, "test-instance", false)
+				require.NoError(err, "Should create collector")
+				c.Workers = workers
+				for player, name := range names {
+					c.uuidCache.Items[player] = uuid.UUIDCacheItem{Name: name, Timestamp: time.Now()}
+				}
+
+				reg := prometheus.NewPedanticRegistry()
+				require.NoError(reg.Register(c), "Should register collector")
+				families, err := reg.Gather()
+				require.NoError(err, "Should gather metrics")
+
+				result := make([]string, 0, len(families))
+				for _, family := range families {
+					if family.GetName() == "minecraft_stat_jumps" {
+						assert.Len(family.GetMetric(), len(names), "Should collect all players")
+					}
+					result = append(result, family.String())
+				}
+
+				if expected == nil {
+					expected = result
+					continue
+				}
+				assert.Equal(expected, result, "Should collect the same metrics with %d workers", workers)
+			}
+		})
+	}
+}
+
+func TestCollectSkipsBrokenPlayers(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	path := newTestWorld(t, "1.20")
+	s, err := NewSave(path)
+	require.NoError(err, "Should create save")
+
+	corruptPlayer := "00000000-0000-0000-0000-000000000001"
+	incompletePlayer := "00000000-0000-0000-0000-000000000002"
+	for _, player := range []string{corruptPlayer, incompletePlayer} {
+		require.NoError(copyFile(filepath.Join(s.statsDir, testUUID+".json"), filepath.Join(s.statsDir, player+".json")))
+		require.NoError(copyFile(filepath.Join(s.advancementsDir, testUUID+".json"), filepath.Join(s.advancementsDir, player+".json")))
+		require.NoError(copyFile(filepath.Join(s.playerDir, testUUID+".dat"), filepath.Join(s.playerDir, player+".dat")))
+	}
+	require.NoError(os.WriteFile(filepath.Join(s.playerDir, corruptPlayer+".dat"), []byte("not nbt"), 0644))
+	require.NoError(os.Remove(filepath.Join(s.advancementsDir, incompletePlayer+".json")))
+
+	c, err := NewSaveCollector(path, "test-instance", false)
+	require.NoError(err, "Should create collector")
+	for player, name := range map[string]string{testUUID: "test-player", corruptPlayer: "corrupt-player", incompletePlayer: "incomplete-player"} {
+		c.uuidCache.Items[player] = uuid.UUIDCacheItem{Name: name, Timestamp: time.Now()}
+	}
+
+	reg := prometheus.NewPedanticRegistry()
+	require.NoError(reg.Register(c), "Should register collector")
+
+	for range 2 {
+		families, err := reg.Gather()
+		require.Error(err, "Should return the failed players")
+		assert.ErrorContains(err, corruptPlayer)
+		assert.ErrorContains(err, incompletePlayer)
+
+		result := make(map[string][]*dto.Metric, len(families))
+		for _, family := range families {
+			result[family.GetName()] = family.GetMetric()
+		}
+
+		require.Len(result["minecraft_stat_jumps"], 1, "Should collect the remaining players")
+		assert.Equal("test-player", labelValue(result["minecraft_stat_jumps"][0], "player"))
+
+		require.Len(result["minecraft_save_up"], 1, "Should report the save as up")
+		assert.Equal(float64(1), result["minecraft_save_up"][0].GetGauge().GetValue())
+
+		hits, misses := c.save.PlayerCacheStats()
+		assert.Equal(float64(hits), result["minecraft_exporter_player_cache_hits_total"][0].GetCounter().GetValue(), "Should report cache metrics")
+		assert.Equal(float64(misses), result["minecraft_exporter_player_cache_misses_total"][0].GetCounter().GetValue(), "Should report cache metrics")
+
+		loadErrors := make(map[string]string)
+		for _, m := range result["minecraft_exporter_player_load_errors_total"] {
+			loadErrors[labelValue(m, "uuid")] = labelValue(m, "reason")
+		}
+		assert.Equal(map[string]string{corruptPlayer: PLAYER_LOAD_ERROR_PLAYERDATA, incompletePlayer: PLAYER_LOAD_ERROR_MISSING_FILE}, loadErrors, "Should count the load errors by reason")
+	}
+	for _, m := range mustGatherFamily(t, reg, "minecraft_exporter_player_load_errors_total") {
+		assert.Equal(float64(3), m.GetCounter().GetValue(), "Should count every failed scrape")
+	}
+
+	require.NoError(os.Remove(filepath.Join(s.statsDir, corruptPlayer+".json")), "Should remove player from the save")
+	metrics := mustGatherFamily(t, reg, "minecraft_exporter_player_load_errors_total")
+	require.Len(metrics, 1, "Should remove the load errors of players that are no longer part of the save")
+	assert.Equal(incompletePlayer, labelValue(metrics[0], "uuid"))
+}
+
+func TestCollectSaveDown(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	path := newTestWorld(t, "1.20")
+	c, err := NewSaveCollector(path, "test-instance", false)
+	require.NoError(err, "Should create collector")
+	require.NoError(os.RemoveAll(c.save.statsDir), "Should remove stats directory")
+
+	reg := prometheus.NewPedanticRegistry()
+	require.NoError(reg.Register(c), "Should register collector")
+
+	families, err := reg.Gather()
+	assert.ErrorContains(err, "minecraft_save_up", "Should return the failure to list the players")
+	require.Len(families, 1, "Should only report the save status")
+	assert.Equal("minecraft_save_up", families[0].GetName())
+	assert.Equal(float64(0), families[0].GetMetric()[0].GetGauge().GetValue())
+}
+
+// Return the metrics of the family with the given name, ignoring gather errors
+func mustGatherFamily(t *testing.T, g prometheus.Gatherer, name string) []*dto.Metric {
+	t.Helper()
+
+	families, _ := g.Gather()
+	for _, family := range families {
+		if family.GetName() == name {
+			return family.GetMetric()
+		}
+	}
+	t.Fatalf("Metric family %s not found", name)
+	return nil
+}
+
+func labelValue(m *dto.Metric, name string) string {
+	for _, label := range m.GetLabel() {
+		if label.GetName() == name {
+			return label.GetValue()
+		}
+	}
+	return ""
+}
+
+func TestCollectPlayerItems(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	s, err := NewSave("./testdata/1.20")
+	require.NoError(err, "Should create save")
+	d, err := s.LoadPlayerData(testUUID)
+	require.NoError(err, "Should load player data")
+
+	reg := prometheus.NewPedanticRegistry()
+	require.NoError(reg.Register(prometheus.CollectorFunc(func(ch chan<- prometheus.Metric) {
+		collectPlayerItems(ch, namespacedIDs([]string{"torch", "minecraft:elytra", "minecraft:torch"}), d.PlayerData, []string{"test-instance", "test-player"})
+	})), "Should register collector")
+
+	families, err := reg.Gather()
+	require.NoError(err, "Should gather metrics")
+	require.Len(families, 1)
+
+	result := make(map[string]float64)
+	for _, m := range families[0].GetMetric() {
+		labels := make(map[string]string, len(m.GetLabel()))
+		for _, label := range m.GetLabel() {
+			labels[label.GetName()] = label.GetValue()
+		}
+		result[labels["item"]+"/"+labels["inventory"]] = m.GetGauge().GetValue()
+	}
+
+	expected := map[string]float64{
+		"minecraft:torch/inventory":    31,
+		"minecraft:torch/ender_chest":  32,
+		"minecraft:elytra/inventory":   0,
+		"minecraft:elytra/ender_chest": 0,
+	}
+	assert.Equal(expected, result, "Should report the configured items")
+}
+
+func TestCollectPlayerStatus(t *testing.T) {
+	tMatrix := []string{"1.12", "1.20", "26"}
+
+	for _, tCase := range tMatrix {
+		t.Run(tCase, func(t *testing.T) {
+			require := require.New(t)
+
+			s, err := NewSave("./testdata/" + tCase)
+			require.NoError(err, "Should create save")
+			d, err := s.LoadPlayerData(testUUID)
+			require.NoError(err, "Should load player data")
+
+			reg := prometheus.NewPedanticRegistry()
+			require.NoError(reg.Register(prometheus.CollectorFunc(func(ch chan<- prometheus.Metric) {
+				collectPlayerStatus(ch, d.PlayerData, []string{"test-instance", "test-player"})
+			})), "Should register collector")
+
+			_, err = reg.Gather()
+			require.NoError(err, "Should not produce duplicate metrics")
+		})
+	}
+}
+
+func TestCollectAdvancements(t *testing.T) {
+	s, err := NewSave("./testdata/1.20")
+	require.NoError(t, err, "Should create save")
+	advancements, err := s.loadAdvancements(testUUID)
+	require.NoError(t, err, "Should load advancements")
+
+	tMatrix := map[string]bool{
+		"Details":   true,
+		"NoDetails": false,
+	}
+	for name, details := range tMatrix {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			reg := prometheus.NewPedanticRegistry()
+			require.NoError(reg.Register(prometheus.CollectorFunc(func(ch chan<- prometheus.Metric) {
+				collectAdvancements(ch, details, advancements, []string{"test-instance", "test-player"})
+			})), "Should register collector")
+
+			families, err := reg.Gather()
+			require.NoError(err, "Should gather metrics")
+
+			result := make(map[string]int, len(families))
+			for _, family := range families {
+				result[family.GetName()] = len(family.GetMetric())
+			}
+
+			expected := map[string]int{"minecraft_player_last_advancement_timestamp_seconds": 1}
+			if details {
+				expected["minecraft_player_advancement_done"] = 47
+				expected["minecraft_player_advancement_completed_timestamp_seconds"] = 42
+			}
+			assert.Equal(expected, result)
+		})
+	}
+}
+
+func TestCollectStatCategories(t *testing.T) {
+	categories := map[string]map[string]int{
+		"create:crushed":      {"minecraft:stone": 5, "minecraft:gravel": 2},
+		"somemod:distance":    {"somemod:jetpack": 300},
+		"minecraft:something": {"minecraft:stone": 1},
+	}
+
+	tMatrix := []struct {
+		Name       string
+		Namespaces []string
+		Result     int
+	}{
+		{"NoFilter", nil, 4},
+		{"Filter", []string{"create"}, 2},
+		{"FilterMultiple", []string{"create", "somemod"}, 3},
+		{"NoMatch", []string{"other"}, 0},
+	}
+
+	for _, tCase := range tMatrix {
+		t.Run(tCase.Name, func(t *testing.T) {
+			ch := make(chan prometheus.Metric)
+			go func() {
+				collectStatCategories(ch, tCase.Namespaces, categories, []string{"test-instance", "test-player"})
+				close(ch)
+			}()
+
+			count := 0
+			for range ch {
+				count++
+			}
+			assert.Equal(t, tCase.Result, count, "Should only collect categories of the given namespaces")
+		})
+	}
+}
+
 func TestUpdateRCONMinecraftVersion(t *testing.T) {
 	assert := assert.New(t)
 	require := require.New(t)
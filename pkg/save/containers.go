@@ -0,0 +1,62 @@
+package save
+
+import (
+	"time"
+)
+
+// Block entities whose inventories are included in the container audit
+var auditContainers = map[string]bool{
+	"minecraft:chest":         true,
+	"minecraft:trapped_chest": true,
+	"minecraft:barrel":        true,
+	"minecraft:shulker_box":   true,
+}
+
+// Counts the items stored in the containers of the world, based on the shared region scanner.
+// The items to count are configured on the region scanner.
+type ContainerAudit struct {
+	regions *RegionScanner
+}
+
+type ContainerAuditResult struct {
+	// Number of the configured items by dimension and item
+	Items map[string]map[string]int
+	// Time at which the scan finished
+	Timestamp time.Time
+	// Time it took to scan the world
+	Duration time.Duration
+}
+
+func NewContainerAudit(regions *RegionScanner) *ContainerAudit {
+	return &ContainerAudit{
+		regions: regions,
+	}
+}
+
+// Scan the containers of all dimensions and sum up the configured items
+func (a *ContainerAudit) Scan() (ContainerAuditResult, error) {
+	start := time.Now()
+	dimensions, err := a.regions.scan()
+	if err != nil {
+		return ContainerAuditResult{}, err
+	}
+	result := ContainerAuditResult{
+		Items: make(map[string]map[string]int, len(dimensions)),
+	}
+	for _, dim := range dimensions {
+		counts := make(map[string]int, len(a.regions.items))
+		for item := range a.regions.items {
+			counts[item] = 0
+		}
+		for _, region := range dim.regions {
+			for item, count := range region.containerItems {
+				counts[item] += count
+			}
+		}
+		result.Items[dim.name] = counts
+	}
+
+	result.Timestamp = time.Now()
+	result.Duration = result.Timestamp.Sub(start)
+	return result, nil
+}
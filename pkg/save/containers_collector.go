@@ -0,0 +1,114 @@
+package save
+
+import (
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Exports the result of the container audit.
+// The audit is expensive, so it runs in the background on its own interval and the last result is exported.
+type ContainerAuditCollector struct {
+	audit    *ContainerAudit
+	Instance string
+
+	lock     sync.RWMutex
+	result   *ContainerAuditResult
+	stop     chan struct{}
+	runOnce  sync.Once
+	stopOnce sync.Once
+}
+
+var (
+	mcWorldContainerItemsDesc         = prometheus.NewDesc("minecraft_world_container_items", "Number of items stored in the chests, barrels and shulker boxes of a dimension", append(worldVariableLabels, "item"), nil)
+	mcWorldContainerAuditTimeDesc     = prometheus.NewDesc("minecraft_world_container_audit_timestamp_seconds", "Time at which the last container audit finished", levelVariableLabels, nil)
+	mcWorldContainerAuditDurationDesc = prometheus.NewDesc("minecraft_world_container_audit_duration_seconds", "Time it took to run the last container audit", levelVariableLabels, nil)
+)
+
+// Create new instance of collector
+// Arguments:
+//
+//	regions: The region scanner of the world, shared with the other collectors of the world
+//	instance: The instance label to use for the metrics
+func NewContainerAuditCollector(regions *RegionScanner, instance string) *ContainerAuditCollector {
+	return &ContainerAuditCollector{
+		audit:    NewContainerAudit(regions),
+		Instance: instance,
+	}
+}
+
+// Run the audit immediately and then in the given interval, until Stop is called.
+// Only the first call starts the audit, further calls are ignored.
+func (c *ContainerAuditCollector) Run(interval time.Duration) {
+	c.runOnce.Do(func() {
+		c.stop = make(chan struct{})
+		go func() {
+			ticker := time.NewTicker(interval)
+			defer ticker.Stop()
+
+			for {
+				c.update()
+				select {
+				case <-ticker.C:
+				case <-c.stop:
+					return
+				}
+			}
+		}()
+	})
+}
+
+// Stop the background audit
+func (c *ContainerAuditCollector) Stop() {
+	c.stopOnce.Do(func() {
+		if c.stop != nil {
+			close(c.stop)
+		}
+	})
+}
+
+// Run the audit and store the result
+func (c *ContainerAuditCollector) update() {
+	slog.Debug("Starting container audit")
+
+	result, err := c.audit.Scan()
+	if err != nil {
+		slog.Error("Failed to audit the containers of the world", "err", err)
+		return
+	}
+
+	c.lock.Lock()
+	c.result = &result
+	c.lock.Unlock()
+
+	slog.Debug("Finished container audit", slog.Duration("duration", result.Duration))
+}
+
+// Implements the Describe function for prometheus.Collector
+func (c *ContainerAuditCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- mcWorldContainerItemsDesc
+	ch <- mcWorldContainerAuditTimeDesc
+	ch <- mcWorldContainerAuditDurationDesc
+}
+
+// Implements the Collect function for prometheus.Collector
+func (c *ContainerAuditCollector) Collect(ch chan<- prometheus.Metric) {
+	c.lock.RLock()
+	result := c.result
+	c.lock.RUnlock()
+
+	// No audit has finished yet
+	if result == nil {
+		return
+	}
+
+	for dim, items := range result.Items {
+		for item, count := range items {
+			ch <- prometheus.MustNewConstMetric(mcWorldContainerItemsDesc, prometheus.GaugeValue, float64(count), c.Instance, dim, item)
+		}
+	}
+	ch <- prometheus.MustNewConstMetric(mcWorldContainerAuditTimeDesc, prometheus.GaugeValue, float64(result.Timestamp.Unix()), c.Instance)
+	ch <- prometheus.MustNewConstMetric(mcWorldContainerAuditDurationDesc, prometheus.GaugeValue, result.Duration.Seconds(), c.Instance)
+}
@@ -0,0 +1,107 @@
+package save
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContainerAudit(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	path := newTestWorld(t, "1.20")
+	legacyShulker := map[string]any{
+		"id": "minecraft:shulker_box", "Count": int8(1),
+		"tag": map[string]any{"BlockEntityTag": map[string]any{"Items": []map[string]any{
+			{"Slot": int8(0), "id": "minecraft:diamond", "Count": int8(64)},
+		}}},
+	}
+	shulker := map[string]any{
+		"id": "minecraft:shulker_box", "count": int32(1),
+		"components": map[string]any{"minecraft:container": []map[string]any{
+			{"slot": int32(0), "item": map[string]any{"id": "minecraft:netherite_ingot", "count": int32(3)}},
+			{"slot": int32(1), "item": map[string]any{
+				"id": "minecraft:bundle", "count": int32(1),
+				"components": map[string]any{"minecraft:bundle_contents": []map[string]any{
+					{"id": "minecraft:diamond", "count": int32(2)},
+				}},
+			}},
+		}},
+	}
+	writeTestRegion(t, path+REGION_DIR+"/r.0.0.mca", []testChunk{
+		{X: 0, Z: 0, Data: map[string]any{"block_entities": []map[string]any{
+			{"id": "minecraft:chest", "Items": []map[string]any{
+				{"Slot": int8(0), "id": "minecraft:diamond", "count": int32(10)},
+				{"Slot": int8(1), "id": "minecraft:dirt", "count": int32(64)},
+			}},
+			{"id": "minecraft:barrel", "Items": []any{shulker}},
+			{"id": "minecraft:hopper", "Items": []map[string]any{
+				{"Slot": int8(0), "id": "minecraft:diamond", "count": int32(5)},
+			}},
+		}}},
+	})
+	writeTestRegion(t, path+NETHER_DIR_LEGACY+REGION_DIR+"/r.0.0.mca", []testChunk{
+		{X: 0, Z: 0, Data: map[string]any{"Level": map[string]any{"TileEntities": []map[string]any{
+			{"id": "minecraft:chest", "Items": []any{legacyShulker}},
+		}}}},
+	})
+	require.NoError(os.WriteFile(path+REGION_DIR+"/r.1.0.mca", make([]byte, 100), 0644), "Should create truncated region")
+
+	s, err := NewSave(path)
+	require.NoError(err, "Should create save")
+	audit := NewContainerAudit(newRegionScanner(s, []string{"diamond", "minecraft:netherite_ingot"}))
+
+	result, err := audit.Scan()
+	require.NoError(err, "Should scan containers")
+
+	expected := map[string]map[string]int{
+		DIMENSION_OVERWORLD:  {"minecraft:diamond": 12, "minecraft:netherite_ingot": 3},
+		DIMENSION_THE_NETHER: {"minecraft:diamond": 64, "minecraft:netherite_ingot": 0},
+	}
+	assert.Equal(expected, result.Items, "Should count items in containers, including nested items")
+	assert.False(result.Timestamp.IsZero(), "Should set the timestamp")
+}
+
+func TestContainerAuditCollector(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	path := newTestWorld(t, "1.20")
+	require.NoError(os.MkdirAll(path+REGION_DIR, 0755))
+
+	regions, err := NewRegionScanner(path, []string{"diamond"})
+	require.NoError(err, "Should create region scanner")
+	c := NewContainerAuditCollector(regions, "test-instance")
+
+	reg := prometheus.NewPedanticRegistry()
+	require.NoError(reg.Register(c), "Should register collector")
+
+	families, err := reg.Gather()
+	require.NoError(err)
+	assert.Empty(families, "Should not export metrics before the first audit")
+
+	c.Run(time.Hour)
+	defer c.Stop()
+	stop := c.stop
+	c.Run(time.Hour)
+	assert.Equal(stop, c.stop, "Should only start the audit once")
+
+	assert.Eventually(func() bool {
+		families, err = reg.Gather()
+		return err == nil && len(families) > 0
+	}, time.Second, 10*time.Millisecond, "Should run the audit in the background")
+
+	names := make([]string, 0, len(families))
+	for _, family := range families {
+		names = append(names, family.GetName())
+	}
+	assert.ElementsMatch([]string{"minecraft_world_container_items", "minecraft_world_container_audit_timestamp_seconds", "minecraft_world_container_audit_duration_seconds"}, names)
+
+	c.Stop()
+	assert.NotPanics(c.Stop, "Should allow stopping multiple times")
+}
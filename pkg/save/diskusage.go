@@ -0,0 +1,92 @@
+package save
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+const (
+	ENTITIES_DIR = "/entities"
+	POI_DIR      = "/poi"
+	DATA_DIR     = "/data"
+)
+
+// Subfolders of a dimension that are included in the disk usage
+var dimensionFolders = map[string]string{
+	"region":   REGION_DIR,
+	"entities": ENTITIES_DIR,
+	"poi":      POI_DIR,
+	"data":     DATA_DIR,
+}
+
+type DiskUsage struct {
+	// Total size of the world directory
+	Total int64
+	// Size of each dimension by subfolder
+	Dimensions map[string]map[string]int64
+	// Size of the player folders (stats, playerdata, advancements), if the world contains player data
+	Players map[string]int64
+}
+
+// Calculate the size on disk of the world, broken down by dimension and subfolder
+func (s *Save) LoadDiskUsage() (DiskUsage, error) {
+	total, err := dirSize(s.worldDir)
+	if err != nil {
+		return DiskUsage{}, err
+	}
+
+	dimensions := s.GetDimensions()
+	usage := DiskUsage{
+		Total:      total,
+		Dimensions: make(map[string]map[string]int64, len(dimensions)),
+		Players:    make(map[string]int64, 3),
+	}
+
+	for _, dim := range dimensions {
+		folders := make(map[string]int64, len(dimensionFolders))
+		for name, folder := range dimensionFolders {
+			size, err := dirSize(dim.Path + folder)
+			if err != nil {
+				return DiskUsage{}, err
+			}
+			folders[name] = size
+		}
+		usage.Dimensions[dim.Name] = folders
+	}
+
+	for name, dir := range map[string]string{"stats": s.statsDir, "playerdata": s.playerDir, "advancements": s.advancementsDir} {
+		// Additional worlds of bukkit based servers do not contain player data
+		if !isDirectory(dir) {
+			continue
+		}
+		size, err := dirSize(dir)
+		if err != nil {
+			return DiskUsage{}, err
+		}
+		usage.Players[name] = size
+	}
+
+	return usage, nil
+}
+
+// Return the combined size of all files in the directory.
+// Returns 0 if the directory does not exist.
+func dirSize(path string) (int64, error) {
+	var size int64
+	err := filepath.WalkDir(path, func(_ string, d fs.DirEntry, err error) error {
+		if err == nil && !d.IsDir() {
+			var info fs.FileInfo
+			info, err = d.Info()
+			if err == nil {
+				size += info.Size()
+			}
+		}
+		// Files might be removed while the server is saving
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	})
+	return size, err
+}
@@ -0,0 +1,65 @@
+package save
+
+import (
+	"log/slog"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type DiskUsageCollector struct {
+	save     *Save
+	Instance string
+}
+
+var (
+	mcWorldSizeDesc           = prometheus.NewDesc("minecraft_world_size_bytes", "Total size of the world directory on disk", []string{"instance"}, nil)
+	mcWorldDimensionSizeDesc  = prometheus.NewDesc("minecraft_world_dimension_size_bytes", "Size of a dimension on disk, by subfolder", append(worldVariableLabels, "folder"), nil)
+	mcWorldPlayerDataSizeDesc = prometheus.NewDesc("minecraft_world_player_data_size_bytes", "Size of the player data on disk, by subfolder", []string{"instance", "folder"}, nil)
+)
+
+// Create new instance of collector, returns error if an world directory is not provided
+// Arguments:
+//
+//	path: The path of the minecraft world directory
+//	instance: The instance label to use for the metrics
+func NewDiskUsageCollector(path, instance string) (*DiskUsageCollector, error) {
+	save, err := NewWorldSave(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DiskUsageCollector{
+		save:     save,
+		Instance: instance,
+	}, nil
+}
+
+// Implements the Describe function for prometheus.Collector
+func (c *DiskUsageCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- mcWorldSizeDesc
+	ch <- mcWorldDimensionSizeDesc
+	ch <- mcWorldPlayerDataSizeDesc
+}
+
+// Implements the Collect function for prometheus.Collector
+func (c *DiskUsageCollector) Collect(ch chan<- prometheus.Metric) {
+	slog.Debug("Starting collection of world disk usage")
+
+	usage, err := c.save.LoadDiskUsage()
+	if err != nil {
+		slog.Error("Failed to calculate disk usage of the world", "err", err)
+		return
+	}
+
+	ch <- prometheus.MustNewConstMetric(mcWorldSizeDesc, prometheus.GaugeValue, float64(usage.Total), c.Instance)
+	for dim, folders := range usage.Dimensions {
+		for folder, size := range folders {
+			ch <- prometheus.MustNewConstMetric(mcWorldDimensionSizeDesc, prometheus.GaugeValue, float64(size), c.Instance, dim, folder)
+		}
+	}
+	for folder, size := range usage.Players {
+		ch <- prometheus.MustNewConstMetric(mcWorldPlayerDataSizeDesc, prometheus.GaugeValue, float64(size), c.Instance, folder)
+	}
+
+	slog.Debug("Finished collection of world disk usage")
+}
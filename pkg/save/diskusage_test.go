@@ -0,0 +1,79 @@
+package save
+
+import (
+	"os"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadDiskUsage(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	path := newTestWorld(t, "1.20")
+	require.NoError(os.MkdirAll(path+REGION_DIR, 0755))
+	require.NoError(os.WriteFile(path+REGION_DIR+"/r.0.0.mca", make([]byte, 8192), 0644))
+	require.NoError(os.MkdirAll(path+ENTITIES_DIR, 0755))
+	require.NoError(os.WriteFile(path+ENTITIES_DIR+"/r.0.0.mca", make([]byte, 4096), 0644))
+	require.NoError(os.MkdirAll(path+NETHER_DIR_LEGACY+REGION_DIR, 0755))
+	require.NoError(os.WriteFile(path+NETHER_DIR_LEGACY+REGION_DIR+"/r.0.0.mca", make([]byte, 100), 0644))
+	require.NoError(os.MkdirAll(path+DIMENSIONS_DIR+"/custom/mining"+REGION_DIR, 0755))
+	require.NoError(os.WriteFile(path+DIMENSIONS_DIR+"/custom/mining"+REGION_DIR+"/r.0.0.mca", make([]byte, 10), 0644))
+
+	s, err := NewSave(path)
+	require.NoError(err, "Should create save")
+
+	usage, err := s.LoadDiskUsage()
+	require.NoError(err, "Should calculate disk usage")
+
+	expected := map[string]map[string]int64{
+		DIMENSION_OVERWORLD:  {"region": 8192, "entities": 4096, "poi": 0, "data": 0},
+		DIMENSION_THE_NETHER: {"region": 100, "entities": 0, "poi": 0, "data": 0},
+		"custom:mining":      {"region": 10, "entities": 0, "poi": 0, "data": 0},
+	}
+	assert.Equal(expected, usage.Dimensions, "Should break down size by dimension and folder")
+
+	stat, err := os.Stat(path + STATS_DIR_LEGACY + "/" + testUUID + ".json")
+	require.NoError(err)
+	assert.Equal(stat.Size(), usage.Players["stats"], "Should contain size of player stats")
+	assert.Greater(usage.Players["playerdata"], int64(0), "Should contain size of player data")
+	assert.Greater(usage.Players["advancements"], int64(0), "Should contain size of player advancements")
+
+	assert.Greater(usage.Total, int64(8192+4096+100+10), "Total should include all files of the world")
+}
+
+func TestDirSizeMissingDirectory(t *testing.T) {
+	size, err := dirSize(t.TempDir() + "/not-a-dir")
+	assert.NoError(t, err, "Should not fail")
+	assert.Equal(t, int64(0), size, "Should return 0")
+}
+
+func TestDiskUsageCollector(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	c, err := NewDiskUsageCollector("not-a-path", "test-instance")
+	assert.Error(err, "Should not create collector with invalid path")
+	assert.Nil(c, "Collector should be nil on error")
+
+	path := newTestWorld(t, "1.20")
+	require.NoError(os.MkdirAll(path+REGION_DIR, 0755))
+
+	c, err = NewDiskUsageCollector(path, "test-instance")
+	require.NoError(err, "Should create collector")
+
+	reg := prometheus.NewPedanticRegistry()
+	require.NoError(reg.Register(c), "Should register collector")
+
+	families, err := reg.Gather()
+	require.NoError(err, "Collected metrics should match the description")
+
+	names := make([]string, 0, len(families))
+	for _, family := range families {
+		names = append(names, family.GetName())
+	}
+	assert.ElementsMatch([]string{"minecraft_world_size_bytes", "minecraft_world_dimension_size_bytes", "minecraft_world_player_data_size_bytes"}, names)
+}
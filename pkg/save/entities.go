@@ -0,0 +1,171 @@
+package save
+
+import (
+	"cmp"
+	"log/slog"
+	"slices"
+	"sync"
+)
+
+type entityChunk struct {
+	Entities []entity `nbt:"Entities"`
+}
+
+type entity struct {
+	ID         string   `nbt:"id"`
+	Passengers []entity `nbt:"Passengers"`
+}
+
+// Counts the entities stored in the entities/ region files of the world.
+// Region files are only read again when they have been modified since the last scan.
+// Worlds from before 1.17 store entities inside the chunks and are not supported.
+// The block entities are counted by the shared region scanner.
+type EntityCensus struct {
+	save    *Save
+	regions *RegionScanner
+	lock    sync.Mutex
+
+	entityRegions *regionCache[entityRegion]
+}
+
+// Result of a scanned entities region file
+type entityRegion struct {
+	// Number of entities by type
+	counts map[string]int
+	// Total number of entities by chunk
+	chunks map[ChunkPos]int
+}
+
+type ChunkPos struct {
+	X, Z int
+}
+
+// The number of entities or block entities inside a chunk
+type ChunkCount struct {
+	Dimension string
+	ChunkPos
+	Count int
+}
+
+type EntityCounts struct {
+	// Number of entities by dimension and type
+	Types map[string]map[string]int
+	// Chunks with the most entities, sorted descending
+	TopEntityChunks []ChunkCount
+	// Chunks with the most block entities, sorted descending
+	TopBlockEntityChunks []ChunkCount
+}
+
+func NewEntityCensus(regions *RegionScanner) *EntityCensus {
+	return &EntityCensus{
+		save:          regions.save,
+		regions:       regions,
+		entityRegions: newRegionCache[entityRegion](),
+	}
+}
+
+// Count the entities of the world by dimension and type.
+// Additionally returns the top n chunks with the most entities and block entities.
+// Block entities are only scanned when n is greater than 0.
+func (e *EntityCensus) Load(n int) (EntityCounts, error) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	dimensions := e.save.GetDimensions()
+	result := EntityCounts{
+		Types: make(map[string]map[string]int, len(dimensions)),
+	}
+	var entityChunks, blockEntityChunks []ChunkCount
+	for _, dim := range dimensions {
+		paths, err := listRegionFiles(dim.Path + ENTITIES_DIR)
+		if err != nil {
+			return EntityCounts{}, err
+		}
+
+		counts := make(map[string]int)
+		regions, _, err := e.entityRegions.getAll(paths, scanRegionFile(scanEntityRegion))
+		if err != nil {
+			return EntityCounts{}, err
+		}
+		for _, region := range regions {
+			for id, count := range region.counts {
+				counts[id] += count
+			}
+			entityChunks = appendChunkCounts(entityChunks, dim.Name, region.chunks)
+		}
+		result.Types[dim.Name] = counts
+	}
+	e.entityRegions.prune()
+
+	if n <= 0 {
+		return result, nil
+	}
+	regionDimensions, err := e.regions.scan()
+	if err != nil {
+		return EntityCounts{}, err
+	}
+	for _, dim := range regionDimensions {
+		for _, region := range dim.regions {
+			blockEntityChunks = appendChunkCounts(blockEntityChunks, dim.name, region.blockEntities)
+		}
+	}
+	result.TopEntityChunks = topChunks(entityChunks, n)
+	result.TopBlockEntityChunks = topChunks(blockEntityChunks, n)
+
+	return result, nil
+}
+
+// Count the entities of all chunks in the region
+func scanEntityRegion(region *Region) entityRegion {
+	result := entityRegion{
+		counts: make(map[string]int),
+		chunks: make(map[ChunkPos]int),
+	}
+	for _, chunk := range region.Chunks() {
+		var data entityChunk
+		err := region.ReadChunk(chunk, &data)
+		if err != nil {
+			slog.Warn("Skipping unreadable entity chunk", "err", err)
+			continue
+		}
+		result.chunks[ChunkPos{X: chunk.X, Z: chunk.Z}] = countEntities(result.counts, data.Entities)
+	}
+	return result
+}
+
+// Count the entities by type, including their passengers. Returns the total number of entities.
+func countEntities(counts map[string]int, entities []entity) int {
+	total := 0
+	for _, entity := range entities {
+		counts[entity.ID]++
+		total += 1 + countEntities(counts, entity.Passengers)
+	}
+	return total
+}
+
+// Add all non-empty chunks to the list
+func appendChunkCounts(list []ChunkCount, dimension string, chunks map[ChunkPos]int) []ChunkCount {
+	for pos, count := range chunks {
+		if count > 0 {
+			list = append(list, ChunkCount{Dimension: dimension, ChunkPos: pos, Count: count})
+		}
+	}
+	return list
+}
+
+// Return the n chunks with the highest count.
+// Ties are broken by dimension and position, to keep the result stable between scrapes.
+func topChunks(chunks []ChunkCount, n int) []ChunkCount {
+	slices.SortFunc(chunks, func(a, b ChunkCount) int {
+		return cmp.Or(
+			cmp.Compare(b.Count, a.Count),
+			cmp.Compare(a.Dimension, b.Dimension),
+			cmp.Compare(a.X, b.X),
+			cmp.Compare(a.Z, b.Z),
+		)
+	})
+	if len(chunks) > n {
+		chunks = chunks[:n]
+	}
+	return chunks
+}
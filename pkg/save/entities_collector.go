@@ -0,0 +1,67 @@
+package save
+
+import (
+	"log/slog"
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type EntityCollector struct {
+	census   *EntityCensus
+	Instance string
+	// Number of chunks with the most entities and block entities to export, disabled when 0
+	TopChunks int
+}
+
+var (
+	mcWorldEntitiesDesc           = prometheus.NewDesc("minecraft_world_entities", "Number of entities saved in a dimension by type", append(worldVariableLabels, "entity"), nil)
+	mcWorldChunkEntitiesDesc      = prometheus.NewDesc("minecraft_world_chunk_entities", "Number of entities in the chunks with the most entities", append(worldVariableLabels, "x", "z"), nil)
+	mcWorldChunkBlockEntitiesDesc = prometheus.NewDesc("minecraft_world_chunk_block_entities", "Number of block entities in the chunks with the most block entities", append(worldVariableLabels, "x", "z"), nil)
+)
+
+// Create new instance of collector
+// Arguments:
+//
+//	regions: The region scanner of the world, shared with the other collectors of the world
+//	instance: The instance label to use for the metrics
+func NewEntityCollector(regions *RegionScanner, instance string) *EntityCollector {
+	return &EntityCollector{
+		census:   NewEntityCensus(regions),
+		Instance: instance,
+	}
+}
+
+// Implements the Describe function for prometheus.Collector
+func (c *EntityCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- mcWorldEntitiesDesc
+	if c.TopChunks > 0 {
+		ch <- mcWorldChunkEntitiesDesc
+		ch <- mcWorldChunkBlockEntitiesDesc
+	}
+}
+
+// Implements the Collect function for prometheus.Collector
+func (c *EntityCollector) Collect(ch chan<- prometheus.Metric) {
+	slog.Debug("Starting collection of saved entities")
+
+	entities, err := c.census.Load(c.TopChunks)
+	if err != nil {
+		slog.Error("Failed to count the entities of the world", "err", err)
+		return
+	}
+
+	for dim, counts := range entities.Types {
+		for id, count := range counts {
+			ch <- prometheus.MustNewConstMetric(mcWorldEntitiesDesc, prometheus.GaugeValue, float64(count), c.Instance, dim, id)
+		}
+	}
+	for _, chunk := range entities.TopEntityChunks {
+		ch <- prometheus.MustNewConstMetric(mcWorldChunkEntitiesDesc, prometheus.GaugeValue, float64(chunk.Count), c.Instance, chunk.Dimension, strconv.Itoa(chunk.X), strconv.Itoa(chunk.Z))
+	}
+	for _, chunk := range entities.TopBlockEntityChunks {
+		ch <- prometheus.MustNewConstMetric(mcWorldChunkBlockEntitiesDesc, prometheus.GaugeValue, float64(chunk.Count), c.Instance, chunk.Dimension, strconv.Itoa(chunk.X), strconv.Itoa(chunk.Z))
+	}
+
+	slog.Debug("Finished collection of saved entities")
+}
@@ -0,0 +1,155 @@
+package save
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testEntityChunk(ids ...string) map[string]any {
+	entities := make([]map[string]any, 0, len(ids))
+	for _, id := range ids {
+		entities = append(entities, map[string]any{"id": id})
+	}
+	return map[string]any{"DataVersion": int32(3465), "Entities": entities}
+}
+
+func TestEntityCensus(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	path := newTestWorld(t, "1.20")
+	require.NoError(os.MkdirAll(path+REGION_DIR, 0755))
+	require.NoError(os.MkdirAll(path+NETHER_DIR_LEGACY+REGION_DIR, 0755))
+	overworld := path + ENTITIES_DIR + "/r.0.0.mca"
+	writeTestRegion(t, overworld, []testChunk{
+		{X: 0, Z: 0, Data: testEntityChunk("minecraft:cow", "minecraft:cow", "minecraft:zombie")},
+		{X: 1, Z: 0, Data: map[string]any{
+			"DataVersion": int32(3465),
+			"Entities": []map[string]any{
+				{"id": "minecraft:spider", "Passengers": []map[string]any{{"id": "minecraft:skeleton"}}},
+			},
+		}},
+	})
+	writeTestRegion(t, path+ENTITIES_DIR+"/r.-1.0.mca", []testChunk{
+		{X: 0, Z: 0, Data: testEntityChunk("minecraft:cow")},
+	})
+	writeTestRegion(t, path+NETHER_DIR_LEGACY+ENTITIES_DIR+"/r.0.0.mca", []testChunk{
+		{X: 0, Z: 0, Data: testEntityChunk("minecraft:ghast")},
+	})
+
+	s, err := NewSave(path)
+	require.NoError(err, "Should create save")
+	census := NewEntityCensus(newRegionScanner(s, nil))
+
+	entities, err := census.Load(0)
+	require.NoError(err, "Should count entities")
+	expected := map[string]map[string]int{
+		DIMENSION_OVERWORLD:  {"minecraft:cow": 3, "minecraft:zombie": 1, "minecraft:spider": 1, "minecraft:skeleton": 1},
+		DIMENSION_THE_NETHER: {"minecraft:ghast": 1},
+	}
+	assert.Equal(expected, entities.Types, "Should count entities and passengers")
+
+	cached := census.entityRegions.entries[overworld]
+	cached.value.counts = map[string]int{"minecraft:cow": 100}
+	census.entityRegions.entries[overworld] = cached
+	entities, err = census.Load(0)
+	require.NoError(err)
+	assert.Equal(101, entities.Types[DIMENSION_OVERWORLD]["minecraft:cow"], "Should use cached counts for unchanged regions")
+
+	modTime := time.Now().Add(time.Minute)
+	require.NoError(os.Chtimes(overworld, modTime, modTime))
+	entities, err = census.Load(0)
+	require.NoError(err)
+	assert.Equal(3, entities.Types[DIMENSION_OVERWORLD]["minecraft:cow"], "Should read modified regions again")
+
+	require.NoError(os.Remove(overworld))
+	entities, err = census.Load(0)
+	require.NoError(err)
+	assert.Equal(map[string]int{"minecraft:cow": 1}, entities.Types[DIMENSION_OVERWORLD], "Should drop deleted regions")
+	assert.NotContains(census.entityRegions.entries, overworld, "Should remove deleted regions from the cache")
+}
+
+func TestEntityCensusTopChunks(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	path := newTestWorld(t, "1.20")
+	require.NoError(os.MkdirAll(path+NETHER_DIR_LEGACY+REGION_DIR, 0755))
+	writeTestRegion(t, path+REGION_DIR+"/r.0.0.mca", []testChunk{
+		{X: 0, Z: 0, Data: map[string]any{"block_entities": []map[string]any{{"id": "minecraft:hopper"}, {"id": "minecraft:chest"}}}},
+		{X: 2, Z: 3, Data: map[string]any{"block_entities": []map[string]any{{"id": "minecraft:spawner"}}}},
+		{X: 4, Z: 4, Data: map[string]any{"block_entities": []map[string]any{}}},
+	})
+	writeTestRegion(t, path+NETHER_DIR_LEGACY+REGION_DIR+"/r.-1.-1.mca", []testChunk{
+		{X: 31, Z: 31, Data: map[string]any{"Level": map[string]any{"TileEntities": []map[string]any{{"id": "minecraft:furnace"}}}}},
+	})
+	writeTestRegion(t, path+ENTITIES_DIR+"/r.0.0.mca", []testChunk{
+		{X: 0, Z: 0, Data: testEntityChunk("minecraft:cow")},
+		{X: 1, Z: 0, Data: testEntityChunk("minecraft:cow", "minecraft:cow", "minecraft:cow")},
+		{X: 5, Z: 0, Data: testEntityChunk("minecraft:item", "minecraft:item")},
+	})
+	// Invalid region files should be skipped without failing the whole census
+	require.NoError(os.WriteFile(path+REGION_DIR+"/r.1.0.mca", make([]byte, 100), 0644))
+	require.NoError(os.WriteFile(path+ENTITIES_DIR+"/r.1.0.mca", make([]byte, 100), 0644))
+
+	s, err := NewSave(path)
+	require.NoError(err, "Should create save")
+	census := NewEntityCensus(newRegionScanner(s, nil))
+
+	entities, err := census.Load(2)
+	require.NoError(err, "Should count entities")
+
+	expectedEntities := []ChunkCount{
+		{Dimension: DIMENSION_OVERWORLD, ChunkPos: ChunkPos{X: 1, Z: 0}, Count: 3},
+		{Dimension: DIMENSION_OVERWORLD, ChunkPos: ChunkPos{X: 5, Z: 0}, Count: 2},
+	}
+	assert.Equal(expectedEntities, entities.TopEntityChunks, "Should return the chunks with the most entities")
+
+	expectedBlockEntities := []ChunkCount{
+		{Dimension: DIMENSION_OVERWORLD, ChunkPos: ChunkPos{X: 0, Z: 0}, Count: 2},
+		{Dimension: DIMENSION_OVERWORLD, ChunkPos: ChunkPos{X: 2, Z: 3}, Count: 1},
+	}
+	assert.Equal(expectedBlockEntities, entities.TopBlockEntityChunks, "Should return the chunks with the most block entities")
+
+	entities, err = census.Load(10)
+	require.NoError(err)
+	assert.Len(entities.TopBlockEntityChunks, 3, "Should skip empty chunks")
+	assert.Contains(entities.TopBlockEntityChunks, ChunkCount{Dimension: DIMENSION_THE_NETHER, ChunkPos: ChunkPos{X: -1, Z: -1}, Count: 1}, "Should read block entities of legacy chunks")
+
+	entities, err = census.Load(0)
+	require.NoError(err)
+	assert.Empty(entities.TopEntityChunks, "Should not return chunks when disabled")
+	assert.Empty(entities.TopBlockEntityChunks, "Should not return chunks when disabled")
+}
+
+func TestEntityCollector(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	path := newTestWorld(t, "1.20")
+	require.NoError(os.MkdirAll(path+REGION_DIR, 0755))
+	writeTestRegion(t, path+ENTITIES_DIR+"/r.0.0.mca", []testChunk{
+		{X: 0, Z: 0, Data: testEntityChunk("minecraft:cow", "minecraft:pig")},
+	})
+
+	regions, err := NewRegionScanner(path, nil)
+	require.NoError(err, "Should create region scanner")
+	c := NewEntityCollector(regions, "test-instance")
+	c.TopChunks = 5
+
+	reg := prometheus.NewPedanticRegistry()
+	require.NoError(reg.Register(c), "Should register collector")
+
+	families, err := reg.Gather()
+	require.NoError(err, "Collected metrics should match the description")
+	result := make(map[string]int, len(families))
+	for _, family := range families {
+		result[family.GetName()] = len(family.GetMetric())
+	}
+	assert.Equal(map[string]int{"minecraft_world_entities": 2, "minecraft_world_chunk_entities": 1}, result)
+}
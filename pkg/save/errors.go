@@ -16,6 +16,27 @@ func (e *ErrNoWorldDirectory) Error() string {
 	return "No valid world directory provided: " + e.details
 }
 
+// Failed to load the data of a player. The reason is used to label the load error metric.
+type ErrLoadPlayer struct {
+	Reason string
+	Err    error
+}
+
+func NewErrLoadPlayer(reason string, err error) *ErrLoadPlayer {
+	return &ErrLoadPlayer{
+		Reason: reason,
+		Err:    err,
+	}
+}
+
+func (e *ErrLoadPlayer) Error() string {
+	return fmt.Sprintf("Failed to load player data (%s): %v", e.Reason, e.Err)
+}
+
+func (e *ErrLoadPlayer) Unwrap() error {
+	return e.Err
+}
+
 func NewErrFailedToParseStat(name string, value int) *ErrFailedToParseStat {
 	return &ErrFailedToParseStat{
 		Name:  name,
@@ -31,3 +52,102 @@ type ErrFailedToParseStat struct {
 func (e *ErrFailedToParseStat) Error() string {
 	return fmt.Sprintf("Failed to parse the stat (\"%s\": %d)", e.Name, e.Value)
 }
+
+type ErrInvalidRegionFile struct {
+	Path    string
+	Details string
+}
+
+func NewErrInvalidRegionFile(path, details string) *ErrInvalidRegionFile {
+	return &ErrInvalidRegionFile{
+		Path:    path,
+		Details: details,
+	}
+}
+
+func (e *ErrInvalidRegionFile) Error() string {
+	return fmt.Sprintf("Invalid region file \"%s\": %s", e.Path, e.Details)
+}
+
+type ErrInvalidChunk struct {
+	Path    string
+	X, Z    int
+	Details string
+}
+
+func NewErrInvalidChunk(path string, chunk ChunkInfo, details string) *ErrInvalidChunk {
+	return &ErrInvalidChunk{
+		Path:    path,
+		X:       chunk.X,
+		Z:       chunk.Z,
+		Details: details,
+	}
+}
+
+func (e *ErrInvalidChunk) Error() string {
+	return fmt.Sprintf("Invalid chunk (%d, %d) in region file \"%s\": %s", e.X, e.Z, e.Path, e.Details)
+}
+
+// The payload of the chunk could not be decompressed
+type ErrDecompressChunk struct {
+	Path string
+	X, Z int
+	Err  error
+}
+
+func NewErrDecompressChunk(path string, chunk ChunkInfo, err error) *ErrDecompressChunk {
+	return &ErrDecompressChunk{
+		Path: path,
+		X:    chunk.X,
+		Z:    chunk.Z,
+		Err:  err,
+	}
+}
+
+func (e *ErrDecompressChunk) Error() string {
+	return fmt.Sprintf("Failed to decompress chunk (%d, %d) in region file \"%s\": %v", e.X, e.Z, e.Path, e.Err)
+}
+
+func (e *ErrDecompressChunk) Unwrap() error {
+	return e.Err
+}
+
+// The decompressed chunk does not contain valid nbt data
+type ErrDecodeChunk struct {
+	Path string
+	X, Z int
+	Err  error
+}
+
+func NewErrDecodeChunk(path string, chunk ChunkInfo, err error) *ErrDecodeChunk {
+	return &ErrDecodeChunk{
+		Path: path,
+		X:    chunk.X,
+		Z:    chunk.Z,
+		Err:  err,
+	}
+}
+
+func (e *ErrDecodeChunk) Error() string {
+	return fmt.Sprintf("Failed to decode nbt data of chunk (%d, %d) in region file \"%s\": %v", e.X, e.Z, e.Path, e.Err)
+}
+
+func (e *ErrDecodeChunk) Unwrap() error {
+	return e.Err
+}
+
+type ErrUnsupportedCompression struct {
+	Path        string
+	Compression byte
+}
+
+func NewErrUnsupportedCompression(path string, compression byte) *ErrUnsupportedCompression {
+	return &ErrUnsupportedCompression{
+		Path:        path,
+		Compression: compression,
+	}
+}
+
+func (e *ErrUnsupportedCompression) Error() string {
+	return fmt.Sprintf("Unsupported chunk compression type %d in region file \"%s\"", e.Compression, e.Path)
+}
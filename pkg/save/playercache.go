@@ -0,0 +1,146 @@
+package save
+
+import (
+	"container/list"
+	"os"
+	"sync"
+	"time"
+)
+
+// Caches the parsed data of the players.
+// Entries are invalidated when the modification time or size of one of the files of the player changes.
+// When the cache is full, the least recently used player is evicted.
+type playerCache struct {
+	lock    sync.Mutex
+	entries map[string]*list.Element
+	// Entries ordered by last use, the least recently used entry is at the back
+	order *list.List
+	// Maximum number of cached players, 0 for no limit
+	maxEntries int
+
+	hits, misses uint64
+}
+
+type playerCacheEntry struct {
+	player string
+	files  []fileState
+	value  PlayerData
+}
+
+// Modification time and size of a file, used to detect changes
+type fileState struct {
+	modTime time.Time
+	size    int64
+}
+
+func newPlayerCache() *playerCache {
+	return &playerCache{
+		entries: make(map[string]*list.Element),
+		order:   list.New(),
+	}
+}
+
+// Return the current state of the given files
+func statFiles(paths ...string) ([]fileState, error) {
+	files := make([]fileState, 0, len(paths))
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, fileState{modTime: info.ModTime(), size: info.Size()})
+	}
+	return files, nil
+}
+
+// Return the cached data of the player, if none of the files changed since it was parsed.
+// The returned data is shared and must not be modified.
+func (c *playerCache) get(player string, files []fileState) (PlayerData, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	element, ok := c.entries[player]
+	if !ok {
+		c.misses++
+		return PlayerData{}, false
+	}
+	cached := element.Value.(playerCacheEntry)
+	if len(cached.files) != len(files) {
+		c.misses++
+		return PlayerData{}, false
+	}
+	for i, file := range files {
+		if !cached.files[i].modTime.Equal(file.modTime) || cached.files[i].size != file.size {
+			c.misses++
+			return PlayerData{}, false
+		}
+	}
+	c.order.MoveToFront(element)
+	c.hits++
+	return cached.value, true
+}
+
+// Store the parsed data of the player together with the state of its files.
+// Evicts the least recently used players when the cache is full.
+func (c *playerCache) add(player string, files []fileState, value PlayerData) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	entry := playerCacheEntry{
+		player: player,
+		files:  files,
+		value:  value,
+	}
+	if element, ok := c.entries[player]; ok {
+		element.Value = entry
+		c.order.MoveToFront(element)
+	} else {
+		c.entries[player] = c.order.PushFront(entry)
+	}
+	c.evict()
+}
+
+// Set the maximum number of cached players, 0 for no limit
+func (c *playerCache) setMaxEntries(maxEntries int) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.maxEntries = max(maxEntries, 0)
+	c.evict()
+}
+
+// Remove the least recently used players until the cache is within its limit.
+// Needs to be called with the lock held.
+func (c *playerCache) evict() {
+	for c.maxEntries > 0 && c.order.Len() > c.maxEntries {
+		element := c.order.Back()
+		c.order.Remove(element)
+		delete(c.entries, element.Value.(playerCacheEntry).player)
+	}
+}
+
+// Remove all players that are not in the given list, e.g. because their files were deleted.
+// The list needs to contain all players of the save, so concurrent collections don't remove each others players.
+func (c *playerCache) prune(players []string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	keep := make(map[string]bool, len(players))
+	for _, player := range players {
+		keep[player] = true
+	}
+	for player, element := range c.entries {
+		if !keep[player] {
+			c.order.Remove(element)
+			delete(c.entries, player)
+		}
+	}
+}
+
+// Return the number of cache hits and misses since the cache was created
+func (c *playerCache) stats() (hits, misses uint64) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.hits, c.misses
+}
@@ -0,0 +1,91 @@
+package save
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlayerCache(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	path := newTestWorld(t, "1.20")
+	s, err := NewSave(path)
+	require.NoError(err, "Should create save")
+
+	expected, err := s.LoadPlayerData(testUUID)
+	require.NoError(err, "Should load player data")
+	hits, misses := s.PlayerCacheStats()
+	assert.Equal(uint64(0), hits)
+	assert.Equal(uint64(1), misses, "Should parse the player on the first load")
+
+	d, err := s.LoadPlayerData(testUUID)
+	require.NoError(err, "Should load player data")
+	assert.Equal(expected, d, "Should return the same data from the cache")
+	hits, misses = s.PlayerCacheStats()
+	assert.Equal(uint64(1), hits, "Should serve unchanged player from the cache")
+	assert.Equal(uint64(1), misses)
+
+	statsFile := filepath.Join(s.statsDir, testUUID+".json")
+	modTime := time.Now().Add(time.Minute)
+	require.NoError(os.Chtimes(statsFile, modTime, modTime))
+	_, err = s.LoadPlayerData(testUUID)
+	require.NoError(err, "Should load player data")
+	hits, misses = s.PlayerCacheStats()
+	assert.Equal(uint64(1), hits)
+	assert.Equal(uint64(2), misses, "Should parse the player again after a file changed")
+
+	require.NoError(os.Remove(statsFile))
+	_, err = s.LoadPlayerData(testUUID)
+	assert.Error(err, "Should not return cached data for removed files")
+}
+
+func TestPlayerCachePrune(t *testing.T) {
+	assert := assert.New(t)
+
+	c := newPlayerCache()
+	files := []fileState{{size: 1}}
+	c.add("a", files, PlayerData{})
+	c.add("b", files, PlayerData{})
+
+	c.prune([]string{"a"})
+	assert.Contains(c.entries, "a", "Should keep players of the save")
+	assert.NotContains(c.entries, "b", "Should remove players that are no longer part of the save")
+	assert.Equal(1, c.order.Len(), "Should remove pruned players from the eviction order")
+
+	_, ok := c.get("a", []fileState{{size: 2}})
+	assert.False(ok, "Should not return player with changed files")
+}
+
+func TestPlayerCacheEviction(t *testing.T) {
+	assert := assert.New(t)
+
+	c := newPlayerCache()
+	c.setMaxEntries(2)
+	files := []fileState{{size: 1}}
+	c.add("a", files, PlayerData{})
+	c.add("b", files, PlayerData{})
+
+	_, ok := c.get("a", files)
+	assert.True(ok, "Should return cached player")
+
+	c.add("c", files, PlayerData{})
+	assert.Len(c.entries, 2, "Should not exceed the maximum number of entries")
+	assert.Contains(c.entries, "a", "Should keep recently used players")
+	assert.NotContains(c.entries, "b", "Should evict the least recently used player")
+	assert.Contains(c.entries, "c", "Should add the new player")
+
+	c.setMaxEntries(1)
+	assert.Len(c.entries, 1, "Should evict players when the limit is lowered")
+	assert.Contains(c.entries, "c")
+
+	c.setMaxEntries(0)
+	c.add("a", files, PlayerData{})
+	c.add("b", files, PlayerData{})
+	assert.Len(c.entries, 3, "Should not limit the cache when 0")
+}
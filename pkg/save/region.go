@@ -0,0 +1,321 @@
+package save
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/Tnze/go-mc/nbt"
+)
+
+const (
+	REGION_SECTOR_SIZE   = 4096
+	REGION_CHUNKS        = 1024
+	REGION_CHUNKS_PER_AX = 32
+	REGION_HEADER_SIZE   = 2 * REGION_SECTOR_SIZE
+
+	COMPRESSION_GZIP         = 1
+	COMPRESSION_ZLIB         = 2
+	COMPRESSION_NONE         = 3
+	COMPRESSION_LZ4          = 4
+	COMPRESSION_CUSTOM       = 127
+	COMPRESSION_EXTERNAL_BIT = 128
+)
+
+// A region file in the anvil format (r.<x>.<z>.mca)
+type Region struct {
+	X, Z int
+
+	path string
+	data []byte
+}
+
+// A chunk contained in a region file
+type ChunkInfo struct {
+	// Absolute chunk coordinates
+	X, Z int
+	// Index of the chunk inside the region header
+	Index int
+	// Offset and length of the chunk in the file, both in sectors
+	Offset, Sectors int
+	// Last time the chunk was saved as unix timestamp
+	Timestamp int64
+}
+
+// Read the region file at the given path into memory.
+// Only the header is validated, chunks are decoded on demand.
+func ReadRegion(path string) (*Region, error) {
+	x, z, err := parseRegionName(filepath.Base(path))
+	if err != nil {
+		return nil, err
+	}
+
+	// #nosec G304: Path is determined dynamically, with only a base directory provided by the user.
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > 0 && len(data) < REGION_HEADER_SIZE {
+		return nil, NewErrInvalidRegionFile(path, fmt.Sprintf("file is smaller than the header (%d bytes)", len(data)))
+	}
+
+	return &Region{
+		X:    x,
+		Z:    z,
+		path: path,
+		data: data,
+	}, nil
+}
+
+// Read the compressed size of every chunk in the region file, like ChunkSize.
+// Only the header and the length in front of each chunk are read, instead of the whole file.
+// Chunks that can't be read are skipped, their errors are returned alongside the sizes.
+func ReadChunkSizes(path string) ([]int, []error, error) {
+	x, z, err := parseRegionName(filepath.Base(path))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// #nosec G304: Path is determined dynamically, with only a base directory provided by the user.
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, nil, err
+	}
+	fileSize := info.Size()
+	if fileSize == 0 {
+		return nil, nil, nil
+	}
+	if fileSize < REGION_HEADER_SIZE {
+		return nil, nil, NewErrInvalidRegionFile(path, fmt.Sprintf("file is smaller than the header (%d bytes)", fileSize))
+	}
+
+	header := make([]byte, REGION_HEADER_SIZE)
+	_, err = io.ReadFull(f, header)
+	if err != nil {
+		return nil, nil, err
+	}
+	region := &Region{X: x, Z: z, path: path, data: header}
+
+	var sizes []int
+	var chunkErrs []error
+	prefix := make([]byte, 5)
+	for _, chunk := range region.Chunks() {
+		start := int64(chunk.Offset) * REGION_SECTOR_SIZE
+		if chunk.Offset < 2 || start+5 > fileSize {
+			chunkErrs = append(chunkErrs, NewErrInvalidChunk(path, chunk, "offset points outside of the file"))
+			continue
+		}
+		_, err := f.ReadAt(prefix, start)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		length := int64(binary.BigEndian.Uint32(prefix))
+		if length < 1 || start+4+length > fileSize {
+			chunkErrs = append(chunkErrs, NewErrInvalidChunk(path, chunk, fmt.Sprintf("invalid length %d", length)))
+			continue
+		}
+		if prefix[4]&COMPRESSION_EXTERNAL_BIT == 0 {
+			sizes = append(sizes, int(length-1))
+			continue
+		}
+
+		external, err := os.Stat(region.externalPath(chunk))
+		if err != nil {
+			chunkErrs = append(chunkErrs, err)
+			continue
+		}
+		sizes = append(sizes, int(external.Size()))
+	}
+	return sizes, chunkErrs, nil
+}
+
+// Return all chunks that have been generated in this region.
+// Minecraft creates empty region files, in which case no chunks are returned.
+func (r *Region) Chunks() []ChunkInfo {
+	if len(r.data) == 0 {
+		return nil
+	}
+
+	chunks := make([]ChunkInfo, 0, REGION_CHUNKS)
+	for i := 0; i < REGION_CHUNKS; i++ {
+		location := binary.BigEndian.Uint32(r.data[i*4:])
+		if location == 0 {
+			continue
+		}
+		chunks = append(chunks, ChunkInfo{
+			X:         r.X*REGION_CHUNKS_PER_AX + i%REGION_CHUNKS_PER_AX,
+			Z:         r.Z*REGION_CHUNKS_PER_AX + i/REGION_CHUNKS_PER_AX,
+			Index:     i,
+			Offset:    int(location >> 8),
+			Sectors:   int(location & 0xff),
+			Timestamp: int64(binary.BigEndian.Uint32(r.data[REGION_SECTOR_SIZE+i*4:])),
+		})
+	}
+	return chunks
+}
+
+// Return the indexes of all chunks that share sectors with another chunk.
+// Chunks pointing outside of the file are ignored.
+func (r *Region) OverlappingChunks() map[int]bool {
+	sectors := len(r.data) / REGION_SECTOR_SIZE
+	owners := make([]int, sectors)
+	overlapping := make(map[int]bool)
+	for _, chunk := range r.Chunks() {
+		if chunk.Offset < 2 || chunk.Offset+chunk.Sectors > sectors {
+			continue
+		}
+		for i := chunk.Offset; i < chunk.Offset+chunk.Sectors; i++ {
+			// Owners are stored as index+1, so that 0 marks a free sector
+			if owner := owners[i]; owner != 0 {
+				overlapping[owner-1] = true
+				overlapping[chunk.Index] = true
+			} else {
+				owners[i] = chunk.Index + 1
+			}
+		}
+	}
+	return overlapping
+}
+
+// Check if the chunk is stored in an external .mcc file, because it is too large for the region
+func (r *Region) IsExternal(chunk ChunkInfo) bool {
+	start := chunk.Offset * REGION_SECTOR_SIZE
+	if chunk.Offset < 2 || start+5 > len(r.data) {
+		return false
+	}
+	return r.data[start+4]&COMPRESSION_EXTERNAL_BIT != 0
+}
+
+// Return the raw payload of the chunk and the compression type it uses.
+// Chunks that are too large for the region are read from the external .mcc file.
+func (r *Region) chunkPayload(chunk ChunkInfo) ([]byte, byte, error) {
+	start := chunk.Offset * REGION_SECTOR_SIZE
+	if chunk.Offset < 2 || start+5 > len(r.data) {
+		return nil, 0, NewErrInvalidChunk(r.path, chunk, "offset points outside of the file")
+	}
+
+	length := int(binary.BigEndian.Uint32(r.data[start:]))
+	if length < 1 || start+4+length > len(r.data) {
+		return nil, 0, NewErrInvalidChunk(r.path, chunk, fmt.Sprintf("invalid length %d", length))
+	}
+	compression := r.data[start+4]
+
+	if compression&COMPRESSION_EXTERNAL_BIT != 0 {
+		// #nosec G304: Path is determined dynamically, with only a base directory provided by the user.
+		payload, err := os.ReadFile(r.externalPath(chunk))
+		if err != nil {
+			return nil, 0, err
+		}
+		return payload, compression &^ COMPRESSION_EXTERNAL_BIT, nil
+	}
+
+	return r.data[start+5 : start+4+length], compression, nil
+}
+
+// Return the path of the external .mcc file of the chunk
+func (r *Region) externalPath(chunk ChunkInfo) string {
+	return filepath.Join(filepath.Dir(r.path), fmt.Sprintf("c.%d.%d.mcc", chunk.X, chunk.Z))
+}
+
+// Return the compressed size of the chunk in bytes
+func (r *Region) ChunkSize(chunk ChunkInfo) (int, error) {
+	payload, _, err := r.chunkPayload(chunk)
+	if err != nil {
+		return 0, err
+	}
+	return len(payload), nil
+}
+
+// Decode the nbt data of the chunk into the given struct
+func (r *Region) ReadChunk(chunk ChunkInfo, target interface{}) error {
+	payload, compression, err := r.chunkPayload(chunk)
+	if err != nil {
+		return err
+	}
+
+	var reader io.Reader
+	switch compression {
+	case COMPRESSION_GZIP:
+		gzipReader, err := gzip.NewReader(bytes.NewReader(payload))
+		if err != nil {
+			return NewErrDecompressChunk(r.path, chunk, err)
+		}
+		defer gzipReader.Close()
+		reader = gzipReader
+	case COMPRESSION_ZLIB:
+		zlibReader, err := zlib.NewReader(bytes.NewReader(payload))
+		if err != nil {
+			return NewErrDecompressChunk(r.path, chunk, err)
+		}
+		defer zlibReader.Close()
+		reader = zlibReader
+	case COMPRESSION_NONE:
+		reader = bytes.NewReader(payload)
+	default:
+		return NewErrUnsupportedCompression(r.path, compression)
+	}
+
+	// Decompress the whole chunk first, so that corrupted payloads can be told apart from invalid nbt data
+	raw, err := io.ReadAll(reader)
+	if err != nil {
+		return NewErrDecompressChunk(r.path, chunk, err)
+	}
+	_, err = nbt.NewDecoder(bytes.NewReader(raw)).Decode(target)
+	if err != nil {
+		return NewErrDecodeChunk(r.path, chunk, err)
+	}
+	return nil
+}
+
+// Parse the region coordinates from a file name like r.<x>.<z>.mca
+func parseRegionName(name string) (int, int, error) {
+	parts := strings.Split(name, ".")
+	if len(parts) != 4 || parts[0] != "r" || parts[3] != "mca" {
+		return 0, 0, NewErrInvalidRegionFile(name, "name does not match r.<x>.<z>.mca")
+	}
+	x, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return 0, 0, NewErrInvalidRegionFile(name, err.Error())
+	}
+	z, err := strconv.Atoi(parts[2])
+	if err != nil {
+		return 0, 0, NewErrInvalidRegionFile(name, err.Error())
+	}
+	return x, z, nil
+}
+
+// Return the paths of all region files in the given directory.
+// Returns an empty list if the directory does not exist.
+func listRegionFiles(dir string) ([]string, error) {
+	files, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	regions := make([]string, 0, len(files))
+	for _, f := range files {
+		if f.IsDir() {
+			continue
+		}
+		if _, _, err := parseRegionName(f.Name()); err == nil {
+			regions = append(regions, filepath.Join(dir, f.Name()))
+		}
+	}
+	return regions, nil
+}
@@ -0,0 +1,229 @@
+package save
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Tnze/go-mc/nbt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testChunk struct {
+	// Position of the chunk inside the region (0-31)
+	X, Z int
+	Data interface{}
+	// Uncompressed payload used instead of the encoded data, e.g. to write invalid nbt
+	Raw []byte
+	// Store the chunk in an external .mcc file
+	External bool
+}
+
+// Write a region file with the given chunks, compressed with zlib
+func writeTestRegion(t *testing.T, path string, chunks []testChunk) {
+	t.Helper()
+
+	var rX, rZ int
+	_, err := fmt.Sscanf(filepath.Base(path), "r.%d.%d.mca", &rX, &rZ)
+	require.NoError(t, err, "Should use a valid region name")
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755), "Should create region directory")
+
+	header := make([]byte, REGION_HEADER_SIZE)
+	var body bytes.Buffer
+	sector := 2
+	for _, chunk := range chunks {
+		raw := chunk.Raw
+		if raw == nil {
+			raw, err = nbt.Marshal(chunk.Data)
+			require.NoError(t, err, "Should encode chunk")
+		}
+		var compressed bytes.Buffer
+		w := zlib.NewWriter(&compressed)
+		_, err = w.Write(raw)
+		require.NoError(t, err, "Should compress chunk")
+		require.NoError(t, w.Close(), "Should compress chunk")
+
+		payload := compressed.Bytes()
+		compression := byte(COMPRESSION_ZLIB)
+		if chunk.External {
+			mcc := filepath.Join(filepath.Dir(path), fmt.Sprintf("c.%d.%d.mcc", rX*32+chunk.X, rZ*32+chunk.Z))
+			require.NoError(t, os.WriteFile(mcc, payload, 0644), "Should write external chunk")
+			payload = nil
+			compression |= COMPRESSION_EXTERNAL_BIT
+		}
+
+		data := make([]byte, 5, 5+len(payload))
+		binary.BigEndian.PutUint32(data, uint32(len(payload)+1))
+		data[4] = compression
+		data = append(data, payload...)
+		sectors := (len(data) + REGION_SECTOR_SIZE - 1) / REGION_SECTOR_SIZE
+		data = append(data, make([]byte, sectors*REGION_SECTOR_SIZE-len(data))...)
+
+		i := chunk.X + chunk.Z*32
+		binary.BigEndian.PutUint32(header[i*4:], uint32(sector<<8|sectors))
+		binary.BigEndian.PutUint32(header[REGION_SECTOR_SIZE+i*4:], 1700000000)
+		body.Write(data)
+		sector += sectors
+	}
+
+	require.NoError(t, os.WriteFile(path, append(header, body.Bytes()...), 0644), "Should write region file")
+}
+
+func TestReadRegion(t *testing.T) {
+	type chunkData struct {
+		DataVersion int32  `nbt:"DataVersion"`
+		Status      string `nbt:"Status"`
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		assert := assert.New(t)
+		require := require.New(t)
+
+		path := filepath.Join(t.TempDir(), "r.-1.2.mca")
+		writeTestRegion(t, path, []testChunk{
+			{X: 0, Z: 0, Data: chunkData{DataVersion: 3465, Status: "minecraft:full"}},
+			{X: 31, Z: 1, Data: chunkData{DataVersion: 3465, Status: "minecraft:features"}},
+			{X: 5, Z: 5, Data: chunkData{DataVersion: 3465, Status: "minecraft:full"}, External: true},
+		})
+
+		region, err := ReadRegion(path)
+		require.NoError(err, "Should read region")
+		assert.Equal(-1, region.X)
+		assert.Equal(2, region.Z)
+
+		chunks := region.Chunks()
+		require.Len(chunks, 3, "Should find all chunks")
+		assert.Equal(-32, chunks[0].X)
+		assert.Equal(64, chunks[0].Z)
+		assert.Equal(-1, chunks[1].X)
+		assert.Equal(65, chunks[1].Z)
+		assert.Equal(int64(1700000000), chunks[0].Timestamp)
+
+		assert.False(region.IsExternal(chunks[0]), "Should be stored in the region")
+		assert.True(region.IsExternal(chunks[2]), "Should be stored in an external file")
+
+		for i, status := range []string{"minecraft:full", "minecraft:features", "minecraft:full"} {
+			var data chunkData
+			assert.NoError(region.ReadChunk(chunks[i], &data), "Should decode chunk")
+			assert.Equal(status, data.Status, "Should decode chunk content")
+
+			size, err := region.ChunkSize(chunks[i])
+			assert.NoError(err, "Should return chunk size")
+			assert.Greater(size, 0, "Should return chunk size")
+		}
+	})
+	t.Run("EmptyRegion", func(t *testing.T) {
+		require := require.New(t)
+
+		path := filepath.Join(t.TempDir(), "r.0.0.mca")
+		require.NoError(os.WriteFile(path, nil, 0644))
+
+		region, err := ReadRegion(path)
+		require.NoError(err, "Should read empty region")
+		require.Empty(region.Chunks(), "Should not contain chunks")
+	})
+	t.Run("InvalidName", func(t *testing.T) {
+		_, err := ReadRegion(filepath.Join(t.TempDir(), "level.dat"))
+		assert.ErrorContains(t, err, "name does not match", "Should fail")
+	})
+	t.Run("TruncatedHeader", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "r.0.0.mca")
+		require.NoError(t, os.WriteFile(path, make([]byte, 100), 0644))
+
+		_, err := ReadRegion(path)
+		assert.ErrorContains(t, err, "smaller than the header", "Should fail")
+	})
+	t.Run("ChunkOutsideOfFile", func(t *testing.T) {
+		assert := assert.New(t)
+		require := require.New(t)
+
+		path := filepath.Join(t.TempDir(), "r.0.0.mca")
+		header := make([]byte, REGION_HEADER_SIZE)
+		binary.BigEndian.PutUint32(header, uint32(10<<8|1))
+		require.NoError(os.WriteFile(path, header, 0644))
+
+		region, err := ReadRegion(path)
+		require.NoError(err, "Should read region")
+		chunks := region.Chunks()
+		require.Len(chunks, 1)
+
+		_, err = region.ChunkSize(chunks[0])
+		assert.ErrorContains(err, "offset points outside of the file", "Should fail")
+	})
+}
+
+func TestReadChunkSizes(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	path := filepath.Join(t.TempDir(), "r.0.0.mca")
+	writeTestRegion(t, path, []testChunk{
+		{X: 0, Z: 0, Data: map[string]any{"Status": "minecraft:full"}},
+		{X: 1, Z: 0, Data: map[string]any{"Status": "minecraft:full"}, External: true},
+		{X: 2, Z: 0, Data: map[string]any{"Status": "minecraft:full"}},
+	})
+	data, err := os.ReadFile(path)
+	require.NoError(err)
+	// Point chunk 2 outside of the file
+	binary.BigEndian.PutUint32(data[8:], uint32(1000<<8|1))
+	require.NoError(os.WriteFile(path, data, 0644))
+
+	region, err := ReadRegion(path)
+	require.NoError(err, "Should read region")
+	var expected []int
+	for _, chunk := range region.Chunks()[:2] {
+		size, err := region.ChunkSize(chunk)
+		require.NoError(err)
+		expected = append(expected, size)
+	}
+
+	sizes, chunkErrs, err := ReadChunkSizes(path)
+	require.NoError(err, "Should read chunk sizes")
+	assert.Equal(expected, sizes, "Should return the same sizes as reading the whole region")
+	require.Len(chunkErrs, 1, "Should return the errors of unreadable chunks")
+	assert.ErrorContains(chunkErrs[0], "offset points outside of the file")
+
+	require.NoError(os.WriteFile(path, make([]byte, 100), 0644))
+	_, _, err = ReadChunkSizes(path)
+	assert.ErrorContains(err, "smaller than the header", "Should fail for truncated region")
+}
+
+func TestOverlappingChunks(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	path := filepath.Join(t.TempDir(), "r.0.0.mca")
+	data := make([]byte, REGION_HEADER_SIZE+4*REGION_SECTOR_SIZE)
+	binary.BigEndian.PutUint32(data[0:], uint32(2<<8|2))
+	binary.BigEndian.PutUint32(data[4:], uint32(3<<8|1))
+	binary.BigEndian.PutUint32(data[8:], uint32(4<<8|2))
+	binary.BigEndian.PutUint32(data[12:], uint32(100<<8|1))
+	require.NoError(os.WriteFile(path, data, 0644))
+
+	region, err := ReadRegion(path)
+	require.NoError(err, "Should read region")
+	assert.Equal(map[int]bool{0: true, 1: true}, region.OverlappingChunks(), "Should only return chunks sharing sectors")
+}
+
+func TestListRegionFiles(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	dir := t.TempDir()
+	for _, name := range []string{"r.0.0.mca", "r.-1.0.mca", "c.0.0.mcc", "r.0.0.mca.tmp"} {
+		require.NoError(os.WriteFile(filepath.Join(dir, name), nil, 0644))
+	}
+
+	regions, err := listRegionFiles(dir)
+	assert.NoError(err, "Should list region files")
+	assert.ElementsMatch([]string{filepath.Join(dir, "r.0.0.mca"), filepath.Join(dir, "r.-1.0.mca")}, regions)
+
+	regions, err = listRegionFiles(filepath.Join(dir, "not-a-dir"))
+	assert.NoError(err, "Should not fail for missing directory")
+	assert.Empty(regions)
+}
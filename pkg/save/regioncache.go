@@ -0,0 +1,99 @@
+package save
+
+import (
+	"errors"
+	"log/slog"
+	"os"
+	"time"
+)
+
+// Caches the result of scanning region files.
+// Entries are invalidated when the modification time or size of the file changes.
+type regionCache[T any] struct {
+	entries map[string]regionCacheEntry[T]
+	seen    map[string]bool
+}
+
+type regionCacheEntry[T any] struct {
+	modTime time.Time
+	size    int64
+	value   T
+}
+
+func newRegionCache[T any]() *regionCache[T] {
+	return &regionCache[T]{
+		entries: make(map[string]regionCacheEntry[T]),
+		seen:    make(map[string]bool),
+	}
+}
+
+// Return a function that reads the whole region file and scans it with the given function
+func scanRegionFile[T any](scan func(*Region) T) func(path string) (T, error) {
+	return func(path string) (T, error) {
+		region, err := ReadRegion(path)
+		if err != nil {
+			var zero T
+			return zero, err
+		}
+		return scan(region), nil
+	}
+}
+
+// Return the result for the region file, only loading it if it changed since the last call.
+// Returns an error satisfying os.IsNotExist if the file has been removed in the meantime.
+func (c *regionCache[T]) get(path string, load func(path string) (T, error)) (T, error) {
+	var zero T
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return zero, err
+	}
+	c.seen[path] = true
+
+	if cached, ok := c.entries[path]; ok && cached.modTime.Equal(info.ModTime()) && cached.size == info.Size() {
+		return cached.value, nil
+	}
+
+	value, err := load(path)
+	if err != nil {
+		return zero, err
+	}
+	c.entries[path] = regionCacheEntry[T]{
+		modTime: info.ModTime(),
+		size:    info.Size(),
+		value:   value,
+	}
+	return value, nil
+}
+
+// Return the results for all given region files.
+// Files that have been removed in the meantime are ignored, invalid region files are skipped and counted.
+func (c *regionCache[T]) getAll(paths []string, load func(path string) (T, error)) ([]T, int, error) {
+	results := make([]T, 0, len(paths))
+	invalid := 0
+	for _, path := range paths {
+		value, err := c.get(path, load)
+		var invalidRegion *ErrInvalidRegionFile
+		if os.IsNotExist(err) {
+			continue
+		} else if errors.As(err, &invalidRegion) {
+			slog.Warn("Skipping invalid region file", "err", err)
+			invalid++
+			continue
+		} else if err != nil {
+			return nil, 0, err
+		}
+		results = append(results, value)
+	}
+	return results, invalid, nil
+}
+
+// Remove all entries that have not been requested since the last prune, e.g. because the region was deleted
+func (c *regionCache[T]) prune() {
+	for path := range c.entries {
+		if !c.seen[path] {
+			delete(c.entries, path)
+		}
+	}
+	c.seen = make(map[string]bool, len(c.entries))
+}
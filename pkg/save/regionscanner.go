@@ -0,0 +1,194 @@
+package save
+
+import (
+	"log/slog"
+	"strings"
+	"sync"
+)
+
+type chunkData struct {
+	// Since 1.18 the chunk data is stored at the top level
+	Status        *string       `nbt:"Status"`
+	InhabitedTime int64         `nbt:"InhabitedTime"`
+	BlockEntities []blockEntity `nbt:"block_entities"`
+	Level         *struct {
+		Status        string        `nbt:"Status"`
+		InhabitedTime int64         `nbt:"InhabitedTime"`
+		TileEntities  []blockEntity `nbt:"TileEntities"`
+	} `nbt:"Level"`
+}
+
+type blockEntity struct {
+	ID    string      `nbt:"id"`
+	Items []ItemStack `nbt:"Items"`
+}
+
+// Return the generation status of the chunk without namespace
+func (c chunkData) GetStatus() string {
+	status := CHUNK_STATUS_UNKNOWN
+	if c.Status != nil {
+		status = *c.Status
+	} else if c.Level != nil && c.Level.Status != "" {
+		status = c.Level.Status
+	}
+	return strings.TrimPrefix(status, "minecraft:")
+}
+
+// Return the number of ticks players have spent inside the chunk
+func (c chunkData) GetInhabitedTime() int64 {
+	if c.Level != nil {
+		return c.Level.InhabitedTime
+	}
+	return c.InhabitedTime
+}
+
+// Return the block entities of the chunk
+func (c chunkData) GetBlockEntities() []blockEntity {
+	if c.Level != nil {
+		return append(c.BlockEntities, c.Level.TileEntities...)
+	}
+	return c.BlockEntities
+}
+
+// Decodes the chunks of all region files of the world in a single pass.
+// The chunk scan, the block entities of the entity census and the container audit are all derived from this pass,
+// so a world only has to be decoded once when several of them are enabled.
+// Region files are only read again when they have been modified since the last scan.
+type RegionScanner struct {
+	save *Save
+	// Items counted in containers, empty if the container audit is disabled
+	items   map[string]bool
+	lock    sync.Mutex
+	regions *regionCache[scannedRegion]
+}
+
+// Result of a decoded region file
+type scannedRegion struct {
+	chunkRegion
+	// Number of block entities by chunk
+	blockEntities map[ChunkPos]int
+	// Number of the configured items stored in containers, by item
+	containerItems map[string]int
+}
+
+// Decoded region files of a dimension
+type dimensionRegions struct {
+	name    string
+	regions []scannedRegion
+	// Number of region files with an invalid name or header
+	invalid int
+}
+
+// Create a new scanner for the world, returns error if an world directory is not provided
+// Arguments:
+//
+//	path: The path of the minecraft world directory
+//	items: The items to count in containers, the namespace defaults to minecraft
+func NewRegionScanner(path string, items []string) (*RegionScanner, error) {
+	save, err := NewWorldSave(path)
+	if err != nil {
+		return nil, err
+	}
+	return newRegionScanner(save, items), nil
+}
+
+func newRegionScanner(save *Save, items []string) *RegionScanner {
+	ids := make(map[string]bool, len(items))
+	for _, item := range items {
+		ids[namespacedID(item)] = true
+	}
+	return &RegionScanner{
+		save:    save,
+		items:   ids,
+		regions: newRegionCache[scannedRegion](),
+	}
+}
+
+// Decode the region files of all dimensions that changed since the last scan.
+// The returned regions are shared with the cache and must not be modified.
+func (s *RegionScanner) scan() ([]dimensionRegions, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	dimensions := s.save.GetDimensions()
+	result := make([]dimensionRegions, 0, len(dimensions))
+	for _, dim := range dimensions {
+		paths, err := listRegionFiles(dim.Path + REGION_DIR)
+		if err != nil {
+			return nil, err
+		}
+		regions, invalid, err := s.regions.getAll(paths, scanRegionFile(s.scanRegion))
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, dimensionRegions{name: dim.Name, regions: regions, invalid: invalid})
+	}
+	s.regions.prune()
+
+	return result, nil
+}
+
+// Decode all chunks of the region
+func (s *RegionScanner) scanRegion(region *Region) scannedRegion {
+	result := scannedRegion{
+		chunkRegion: chunkRegion{
+			statuses:  make(map[string]int),
+			corrupted: make(map[string]int),
+			inhabited: make(map[ChunkPos]int64),
+		},
+		blockEntities:  make(map[ChunkPos]int),
+		containerItems: make(map[string]int),
+	}
+	overlapping := region.OverlappingChunks()
+	for _, chunk := range region.Chunks() {
+		if region.IsExternal(chunk) {
+			result.oversized++
+		}
+		if overlapping[chunk.Index] {
+			slog.Warn("Skipping chunk that overlaps with another chunk", slog.String("path", region.path), slog.Int("x", chunk.X), slog.Int("z", chunk.Z))
+			result.corrupted[CORRUPTION_OVERLAP]++
+			continue
+		}
+
+		var data chunkData
+		err := region.ReadChunk(chunk, &data)
+		if err != nil {
+			slog.Warn("Skipping unreadable chunk", "err", err)
+			if reason, ok := corruptionReason(err); ok {
+				result.corrupted[reason]++
+			}
+			continue
+		}
+		pos := ChunkPos{X: chunk.X, Z: chunk.Z}
+		status := data.GetStatus()
+		result.statuses[status]++
+		if status == CHUNK_STATUS_FULL {
+			result.generated = append(result.generated, pos)
+		}
+		if ticks := data.GetInhabitedTime(); ticks > 0 {
+			result.inhabited[pos] = ticks
+		}
+
+		blockEntities := data.GetBlockEntities()
+		result.blockEntities[pos] = len(blockEntities)
+		if len(s.items) == 0 {
+			continue
+		}
+		for _, blockEntity := range blockEntities {
+			if auditContainers[blockEntity.ID] {
+				s.countItems(result.containerItems, blockEntity.Items)
+			}
+		}
+	}
+	return result
+}
+
+// Add the configured items to the counts, including the contents of nested shulker boxes and bundles
+func (s *RegionScanner) countItems(counts map[string]int, items []ItemStack) {
+	for _, item := range items {
+		if s.items[item.ID] {
+			counts[item.ID] += item.GetCount()
+		}
+		s.countItems(counts, item.NestedItems())
+	}
+}
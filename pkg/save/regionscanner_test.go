@@ -0,0 +1,57 @@
+package save
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRegionScanner(t *testing.T) {
+	assert := assert.New(t)
+
+	s, err := NewRegionScanner("not-a-path", nil)
+	assert.Error(err, "Should not create scanner with invalid path")
+	assert.Nil(s, "Scanner should be nil on error")
+
+	s, err = NewRegionScanner(newTestWorld(t, "1.20"), []string{"diamond", "minecraft:dirt"})
+	assert.NoError(err, "Should create scanner with valid path")
+	assert.Equal(map[string]bool{"minecraft:diamond": true, "minecraft:dirt": true}, s.items, "Should add the default namespace to the items")
+}
+
+func TestRegionScannerShared(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	path := newTestWorld(t, "1.20")
+	region := path + REGION_DIR + "/r.0.0.mca"
+	writeTestRegion(t, region, []testChunk{
+		{X: 0, Z: 0, Data: map[string]any{"Status": "minecraft:full", "block_entities": []map[string]any{
+			{"id": "minecraft:chest", "Items": []map[string]any{
+				{"Slot": int8(0), "id": "minecraft:diamond", "count": int32(10)},
+			}},
+		}}},
+	})
+
+	s, err := NewSave(path)
+	require.NoError(err, "Should create save")
+	regions := newRegionScanner(s, []string{"diamond"})
+
+	chunks, err := NewChunkScanner(regions).Scan(0)
+	require.NoError(err, "Should scan chunks")
+	assert.Equal(map[string]int{"full": 1}, chunks.Statuses[DIMENSION_OVERWORLD])
+	require.Contains(regions.regions.entries, region, "Should cache the decoded region")
+
+	cached := regions.regions.entries[region]
+	cached.value.containerItems = map[string]int{"minecraft:diamond": 100}
+	cached.value.blockEntities = map[ChunkPos]int{{X: 0, Z: 0}: 50}
+	regions.regions.entries[region] = cached
+
+	audit, err := NewContainerAudit(regions).Scan()
+	require.NoError(err, "Should scan containers")
+	assert.Equal(100, audit.Items[DIMENSION_OVERWORLD]["minecraft:diamond"], "Should reuse the region decoded by the chunk scan")
+
+	entities, err := NewEntityCensus(regions).Load(1)
+	require.NoError(err, "Should count entities")
+	assert.Equal([]ChunkCount{{Dimension: DIMENSION_OVERWORLD, ChunkPos: ChunkPos{X: 0, Z: 0}, Count: 50}}, entities.TopBlockEntityChunks, "Should reuse the region decoded by the chunk scan")
+}
@@ -1,7 +1,9 @@
 package save
 
 import (
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"strings"
@@ -17,16 +19,74 @@ const (
 	STATS_DIR_LEGACY        = "/stats"
 	PLAYER_DIR_LEGACY       = "/playerdata"
 	ADVANCEMENTS_DIR_LEGACY = "/advancements"
+
+	DATA_DIR_NAMESPACED = "/data/minecraft"
+
+	REGION_DIR        = "/region"
+	DIMENSIONS_DIR    = "/dimensions"
+	NETHER_DIR_LEGACY = "/DIM-1"
+	END_DIR_LEGACY    = "/DIM1"
+)
+
+// Reasons why the data of a player could not be loaded
+const (
+	PLAYER_LOAD_ERROR_UUID_LOOKUP  = "uuid_lookup"
+	PLAYER_LOAD_ERROR_MISSING_FILE = "missing_file"
+	PLAYER_LOAD_ERROR_IO           = "io"
+	PLAYER_LOAD_ERROR_ADVANCEMENTS = "advancements"
+	PLAYER_LOAD_ERROR_STATS        = "stats"
+	PLAYER_LOAD_ERROR_PLAYERDATA   = "playerdata"
+	PLAYER_LOAD_ERROR_UNKNOWN      = "unknown"
+)
+
+const (
+	DIMENSION_OVERWORLD  = "minecraft:overworld"
+	DIMENSION_THE_NETHER = "minecraft:the_nether"
+	DIMENSION_THE_END    = "minecraft:the_end"
+
+	DIMENSION_DIR_OVERWORLD  = "/minecraft/overworld"
+	DIMENSION_DIR_THE_NETHER = "/minecraft/the_nether"
+	DIMENSION_DIR_THE_END    = "/minecraft/the_end"
 )
 
 type Save struct {
-	worldDir, statsDir, playerDir, advancementsDir string
+	worldDir, statsDir, playerDir, advancementsDir, dataDir string
+	dimensions                                              []Dimension
+	playerCache                                             *playerCache
+	worldStats                                              *worldStatsCache
 
 	Version MinecraftVersion
 }
 
-// Create a new save from the given path
+// A dimension of the world and the directory containing its data
+type Dimension struct {
+	Name string
+	Path string
+}
+
+// Create a new save from the given path, the save needs to contain player data
 func NewSave(path string) (*Save, error) {
+	s, err := NewWorldSave(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isDirectory(s.statsDir) {
+		return nil, NewErrNoWorldDirectory("Failed to find player stats subdirectory")
+	}
+	if !isDirectory(s.playerDir) {
+		return nil, NewErrNoWorldDirectory("Failed to find player data subdirectory")
+	}
+	if !isDirectory(s.advancementsDir) {
+		return nil, NewErrNoWorldDirectory("Failed to find player advancements subdirectory")
+	}
+
+	return s, nil
+}
+
+// Create a new save from the given path, without requiring player data.
+// Bukkit based servers store the nether and end as separate worlds, which do not contain player data.
+func NewWorldSave(path string) (*Save, error) {
 	if !isDirectory(path) {
 		return nil, NewErrNoWorldDirectory("\"" + path + "\"" + " is not a directory")
 	}
@@ -36,15 +96,28 @@ func NewSave(path string) (*Save, error) {
 		return nil, NewErrNoWorldDirectory(fmt.Sprintf("Failed to read minecraft version: %v", err))
 	}
 
-	var statsDir, playerDir, advancementsDir string
+	var statsDir, playerDir, advancementsDir, dataDir string
+	var dimensions []Dimension
 	if utils.VersionGreaterOrEqual(utils.VERSION_26, version.Name) {
 		statsDir = path + STATS_DIR
 		playerDir = path + PLAYER_DIR
 		advancementsDir = path + ADVANCEMENTS_DIR
+		dataDir = path + DATA_DIR_NAMESPACED
+		dimensions = []Dimension{
+			{Name: DIMENSION_OVERWORLD, Path: path + DIMENSIONS_DIR + DIMENSION_DIR_OVERWORLD},
+			{Name: DIMENSION_THE_NETHER, Path: path + DIMENSIONS_DIR + DIMENSION_DIR_THE_NETHER},
+			{Name: DIMENSION_THE_END, Path: path + DIMENSIONS_DIR + DIMENSION_DIR_THE_END},
+		}
 	} else {
 		statsDir = path + STATS_DIR_LEGACY
 		playerDir = path + PLAYER_DIR_LEGACY
 		advancementsDir = path + ADVANCEMENTS_DIR_LEGACY
+		dataDir = path + DATA_DIR
+		dimensions = []Dimension{
+			{Name: DIMENSION_OVERWORLD, Path: path},
+			{Name: DIMENSION_THE_NETHER, Path: path + NETHER_DIR_LEGACY},
+			{Name: DIMENSION_THE_END, Path: path + END_DIR_LEGACY},
+		}
 	}
 
 	s := &Save{
@@ -52,19 +125,13 @@ func NewSave(path string) (*Save, error) {
 		statsDir:        statsDir,
 		playerDir:       playerDir,
 		advancementsDir: advancementsDir,
+		dataDir:         dataDir,
+		dimensions:      dimensions,
+		playerCache:     newPlayerCache(),
+		worldStats:      newWorldStatsCache(),
 
 		Version: version,
 	}
-	if !isDirectory(s.statsDir) {
-		return nil, NewErrNoWorldDirectory("Failed to find player stats subdirectory")
-	}
-	if !isDirectory(s.playerDir) {
-		return nil, NewErrNoWorldDirectory("Failed to find player data subdirectory")
-	}
-	if !isDirectory(s.advancementsDir) {
-		return nil, NewErrNoWorldDirectory("Failed to find player advancements subdirectory")
-	}
-
 	return s, nil
 }
 
@@ -86,22 +153,83 @@ func (s *Save) GetPlayers() ([]string, error) {
 	return players, nil
 }
 
-// Load all relevant data for the given player
+// Return all dimensions of the save that have been generated.
+// Includes custom dimensions added by datapacks under dimensions/<namespace>/<name>.
+func (s *Save) GetDimensions() []Dimension {
+	dimensions := make([]Dimension, 0, len(s.dimensions))
+	known := make(map[string]bool, len(s.dimensions))
+	for _, dim := range s.dimensions {
+		known[dim.Name] = true
+		if isDirectory(dim.Path + REGION_DIR) {
+			dimensions = append(dimensions, dim)
+		}
+	}
+
+	for _, dim := range findCustomDimensions(s.worldDir + DIMENSIONS_DIR) {
+		if !known[dim.Name] {
+			dimensions = append(dimensions, dim)
+		}
+	}
+	return dimensions
+}
+
+// Load the current state of the world from level.dat
+func (s *Save) LoadLevelData() (LevelData, error) {
+	return readLevelDat(s.worldDir)
+}
+
+// Load all relevant data for the given player.
+// The parsed data is cached until one of the files of the player changes, so it must not be modified.
 func (s *Save) LoadPlayerData(player string) (PlayerData, error) {
-	advancements, err := s.loadAdvancements(player)
+	files, err := statFiles(
+		filepath.Join(s.advancementsDir, player+".json"),
+		filepath.Join(s.statsDir, player+".json"),
+		filepath.Join(s.playerDir, player+".dat"),
+	)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return PlayerData{}, NewErrLoadPlayer(PLAYER_LOAD_ERROR_MISSING_FILE, err)
+		}
+		return PlayerData{}, NewErrLoadPlayer(PLAYER_LOAD_ERROR_IO, err)
+	}
+	if data, ok := s.playerCache.get(player, files); ok {
+		return data, nil
+	}
+
+	data, err := s.parsePlayerData(player)
 	if err != nil {
 		return PlayerData{}, err
 	}
+	s.playerCache.add(player, files, data)
+	return data, nil
+}
+
+// Set the maximum number of players kept in the cache, 0 for no limit
+func (s *Save) SetPlayerCacheSize(size int) {
+	s.playerCache.setMaxEntries(size)
+}
+
+// Return the number of times the player data was served from the cache and the number of times it had to be parsed
+func (s *Save) PlayerCacheStats() (hits, misses uint64) {
+	return s.playerCache.stats()
+}
+
+// Parse the data of the given player from the save
+func (s *Save) parsePlayerData(player string) (PlayerData, error) {
+	advancements, err := s.loadAdvancements(player)
+	if err != nil {
+		return PlayerData{}, NewErrLoadPlayer(PLAYER_LOAD_ERROR_ADVANCEMENTS, err)
+	}
 
 	stats, err := s.loadStats(player)
 	if err != nil {
-		return PlayerData{}, err
+		return PlayerData{}, NewErrLoadPlayer(PLAYER_LOAD_ERROR_STATS, err)
 	}
 
 	var data MinecraftPlayerData
 	err = readNBT(filepath.Join(s.playerDir, player+".dat"), &data)
 	if err != nil {
-		return PlayerData{}, err
+		return PlayerData{}, NewErrLoadPlayer(PLAYER_LOAD_ERROR_PLAYERDATA, err)
 	}
 
 	return PlayerData{
@@ -171,6 +299,20 @@ func (s *Save) loadStatsPre115(player string) (Stats, error) {
 		case "entityKilledBy":
 			name := strings.Join(key[2:], ":")
 			stats.KilledBy[name] = value
+		case "useItem":
+			name := strings.Join(key[2:], ":")
+			stats.Used[name] = value
+		case "breakItem":
+			name := strings.Join(key[2:], ":")
+			stats.Broken[name] = value
+		case "drop":
+			// "stat.drop" without an item counts the number of drop actions
+			if len(key) < 3 {
+				stats.Custom.Custom["drop"] = value
+				continue
+			}
+			name := strings.Join(key[2:], ":")
+			stats.Dropped[name] = value
 		case "jump":
 			stats.Custom.Jump = value
 		case "deaths":
@@ -5,8 +5,11 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
+	"github.com/Tnze/go-mc/nbt"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -30,6 +33,14 @@ func TestNewSave(t *testing.T) {
 			statsDir:        path + STATS_DIR_LEGACY,
 			playerDir:       path + PLAYER_DIR_LEGACY,
 			advancementsDir: path + ADVANCEMENTS_DIR_LEGACY,
+			dataDir:         path + DATA_DIR,
+			dimensions: []Dimension{
+				{Name: DIMENSION_OVERWORLD, Path: path},
+				{Name: DIMENSION_THE_NETHER, Path: path + NETHER_DIR_LEGACY},
+				{Name: DIMENSION_THE_END, Path: path + END_DIR_LEGACY},
+			},
+			playerCache: newPlayerCache(),
+			worldStats:  newWorldStatsCache(),
 			Version: MinecraftVersion{
 				Id:       3465,
 				Name:     "1.20.1",
@@ -52,6 +63,14 @@ func TestNewSave(t *testing.T) {
 			statsDir:        path + STATS_DIR,
 			playerDir:       path + PLAYER_DIR,
 			advancementsDir: path + ADVANCEMENTS_DIR,
+			dataDir:         path + DATA_DIR_NAMESPACED,
+			dimensions: []Dimension{
+				{Name: DIMENSION_OVERWORLD, Path: path + DIMENSIONS_DIR + DIMENSION_DIR_OVERWORLD},
+				{Name: DIMENSION_THE_NETHER, Path: path + DIMENSIONS_DIR + DIMENSION_DIR_THE_NETHER},
+				{Name: DIMENSION_THE_END, Path: path + DIMENSIONS_DIR + DIMENSION_DIR_THE_END},
+			},
+			playerCache: newPlayerCache(),
+			worldStats:  newWorldStatsCache(),
 			Version: MinecraftVersion{
 				Id:       4790,
 				Name:     "26.1.2",
@@ -187,15 +206,25 @@ func TestGetPlayers(t *testing.T) {
 func TestLoadPlayerData(t *testing.T) {
 	tMatrix := []struct {
 		Name                                                     string
-		LAdvancements                                            uint
+		LAdvancements, LRecipes                                  uint
 		LCustom, LCrafted, LMined, LPickedUp, LKilled, LKilledBy int
+		LUsed, LBroken, LDropped                                 int
+		LInventory, LEnderChest                                  int
+		LEffects, LAttributes                                    int
 		PlayerData                                               MinecraftPlayerData
 		Stats                                                    CustomStats
 	}{
 		{
 			Name:          "1.12",
+			LEffects:      6,
+			LAttributes:   12,
+			LInventory:    10,
+			LEnderChest:   6,
 			LAdvancements: 172,
-			LCustom:       185,
+			LCustom:       16,
+			LUsed:         138,
+			LBroken:       0,
+			LDropped:      31,
 			LCrafted:      378,
 			LMined:        169,
 			LPickedUp:     367,
@@ -203,6 +232,11 @@ func TestLoadPlayerData(t *testing.T) {
 			LKilledBy:     0,
 			PlayerData: MinecraftPlayerData{
 				XPTotal: 1604582, XPLevel: 554, Score: 1604867, Health: 156.79771423339844, FoodLevel: 20,
+				GameMode: 0, Air: 300, Fire: -20, XPProgress: 0.07216056436300278,
+				Pos:       []float64{1.3515575411331766, 65, 7.25050118041949},
+				Rotation:  []float32{202.65318, 9.899909},
+				Dimension: int32(0),
+				SpawnX:    intPtr(9), SpawnY: 72, SpawnZ: -4,
 			},
 			Stats: CustomStats{
 				Jump:        4723,
@@ -225,15 +259,29 @@ func TestLoadPlayerData(t *testing.T) {
 		},
 		{
 			Name:          "1.20",
-			LAdvancements: 284,
+			LEffects:      0,
+			LAttributes:   6,
+			LInventory:    11,
+			LEnderChest:   4,
+			LAdvancements: 42,
+			LRecipes:      242,
 			LCustom:       19,
 			LCrafted:      37,
 			LMined:        45,
 			LPickedUp:     88,
 			LKilled:       8,
 			LKilledBy:     4,
+			LUsed:         46,
+			LBroken:       2,
+			LDropped:      20,
 			PlayerData: MinecraftPlayerData{
 				XPTotal: 38, XPLevel: 3, Score: 850, Health: 20, FoodLevel: 20,
+				GameMode: 3, Air: 300, Fire: 0, FoodSaturation: 11, XPProgress: 0.8461537957191467,
+				Pos:       []float64{-276.6451272432159, 70.37432929748516, -362.2441656328231},
+				Rotation:  []float32{-51.234196, 11.853942},
+				Dimension: DIMENSION_OVERWORLD,
+				SpawnX:    intPtr(18), SpawnY: 63, SpawnZ: -42, SpawnDimension: DIMENSION_OVERWORLD,
+				LastDeathLocation: &GlobalPos{Pos: []int32{1, 68, 6}, Dimension: DIMENSION_OVERWORLD},
 			},
 			Stats: CustomStats{
 				Jump:        3905,
@@ -256,15 +304,29 @@ func TestLoadPlayerData(t *testing.T) {
 		},
 		{
 			Name:          "26",
-			LAdvancements: 304,
+			LEffects:      0,
+			LAttributes:   10,
+			LInventory:    14,
+			LEnderChest:   0,
+			LAdvancements: 16,
+			LRecipes:      288,
 			LCustom:       13,
 			LCrafted:      43,
 			LMined:        45,
 			LPickedUp:     76,
 			LKilled:       7,
 			LKilledBy:     2,
+			LUsed:         34,
+			LBroken:       1,
+			LDropped:      2,
 			PlayerData: MinecraftPlayerData{
 				XPTotal: 3, XPLevel: 0, Score: 383, Health: 20, FoodLevel: 20,
+				GameMode: 0, Air: 300, Fire: -20, FoodSaturation: 3.8000001907348633, XPProgress: 0.4285714328289032,
+				Pos:               []float64{66.8903365057262, -2, 2.4890875099839125},
+				Rotation:          []float32{10.806152, 8.249951},
+				Dimension:         DIMENSION_OVERWORLD,
+				Respawn:           &GlobalPos{Pos: []int32{64, -2, 4}, Dimension: DIMENSION_OVERWORLD},
+				LastDeathLocation: &GlobalPos{Pos: []int32{-614, 64, 295}, Dimension: DIMENSION_OVERWORLD},
 			},
 			Stats: CustomStats{
 				Jump:        2568,
@@ -303,14 +365,31 @@ func TestLoadPlayerData(t *testing.T) {
 
 			// Details are not necessary here
 			assert.Equal(tCase.LAdvancements, countAdvancements(d.Advancements), "Advancements")
+			assert.Equal(tCase.LRecipes, countRecipes(d.Advancements), "Recipes")
 			assert.Equal(tCase.LCustom, len(d.Stats.Custom.Custom), "Custom")
 			assert.Equal(tCase.LCrafted, len(d.Stats.CraftedItems), "CraftedItems")
 			assert.Equal(tCase.LMined, len(d.Stats.Mined), "Mined")
 			assert.Equal(tCase.LPickedUp, len(d.Stats.PickedUp), "PickedUp")
 			assert.Equal(tCase.LKilled, len(d.Stats.Killed), "Killed")
 			assert.Equal(tCase.LKilledBy, len(d.Stats.KilledBy), "KilledBy")
+			assert.Equal(tCase.LUsed, len(d.Stats.Used), "Used")
+			assert.Equal(tCase.LBroken, len(d.Stats.Broken), "Broken")
+			assert.Equal(tCase.LDropped, len(d.Stats.Dropped), "Dropped")
+
+			assert.Equal(tCase.LInventory, len(d.PlayerData.InventoryItems()), "Inventory")
+			assert.Equal(tCase.LEnderChest, len(d.PlayerData.EnderChestItems()), "EnderChest")
+
+			assert.Equal(tCase.LEffects, len(d.PlayerData.GetEffects()), "Effects")
+			assert.Equal(tCase.LAttributes, len(d.PlayerData.GetAttributes()), "Attributes")
 
 			d.Stats.Custom.Custom = nil
+			d.PlayerData.ActiveEffects = nil
+			d.PlayerData.ActiveEffectsV2 = nil
+			d.PlayerData.Attributes = nil
+			d.PlayerData.AttributesV2 = nil
+			d.PlayerData.Inventory = nil
+			d.PlayerData.EnderItems = nil
+			d.PlayerData.Equipment = nil
 
 			assert.Equal(tCase.PlayerData, d.PlayerData)
 			assert.Equal(tCase.Stats, d.Stats.Custom)
@@ -327,6 +406,311 @@ func TestLoadPlayerData(t *testing.T) {
 
 }
 
+func TestPlayerDataGetters(t *testing.T) {
+	t.Run("LegacyDimension", func(t *testing.T) {
+		assert := assert.New(t)
+
+		d := MinecraftPlayerData{Dimension: int32(-1), SpawnX: intPtr(1), SpawnY: 2, SpawnZ: 3}
+		assert.Equal(DIMENSION_THE_NETHER, d.GetDimension())
+		spawn, ok := d.GetSpawn()
+		assert.True(ok, "Should have spawn")
+		assert.Equal(GlobalPos{Pos: []int32{1, 2, 3}, Dimension: DIMENSION_OVERWORLD}, spawn, "Legacy spawn should be in the overworld")
+
+		d.Dimension = int32(7)
+		assert.Equal("7", d.GetDimension(), "Should keep modded dimension ids")
+	})
+	t.Run("Empty", func(t *testing.T) {
+		assert := assert.New(t)
+
+		d := MinecraftPlayerData{}
+		assert.Equal(DIMENSION_OVERWORLD, d.GetDimension())
+		_, ok := d.GetPosition()
+		assert.False(ok, "Should not have a position")
+		_, ok = d.GetSpawn()
+		assert.False(ok, "Should not have a spawn")
+	})
+	t.Run("Respawn", func(t *testing.T) {
+		d := MinecraftPlayerData{
+			SpawnX:  intPtr(1),
+			Respawn: &GlobalPos{Pos: []int32{4, 5, 6}, Dimension: DIMENSION_THE_END},
+		}
+		spawn, ok := d.GetSpawn()
+		assert.True(t, ok, "Should have spawn")
+		assert.Equal(t, *d.Respawn, spawn, "Should prefer respawn")
+	})
+}
+
+func TestLoadStatsUnmappedCategories(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	path := newTestWorld(t, "1.20")
+	statsFile := path + STATS_DIR_LEGACY + "/" + testUUID + ".json"
+	data := `{"stats":{"minecraft:mined":{"minecraft:stone":3},"create:crushed":{"minecraft:stone":5}},"DataVersion":3465}`
+	require.NoError(os.WriteFile(statsFile, []byte(data), 0644))
+
+	s, err := NewSave(path)
+	require.NoError(err, "Should create save")
+
+	stats, err := s.loadStats(testUUID)
+	require.NoError(err, "Should load stats")
+	assert.Equal(map[string]int{"minecraft:stone": 3}, stats.Mined, "Should still decode mapped categories")
+	assert.Equal(map[string]map[string]int{"create:crushed": {"minecraft:stone": 5}}, stats.Other, "Should keep unmapped categories")
+}
+
+func TestAdvancementCompletedAt(t *testing.T) {
+	assert := assert.New(t)
+
+	a := Advancement{
+		Done: true,
+		Criteria: map[string]string{
+			"minecraft:plains": "2023-06-14 16:08:07 +0200",
+			"minecraft:desert": "2023-06-15 10:00:00 +0200",
+		},
+	}
+	completed, ok := a.CompletedAt()
+	assert.True(ok, "Should be completed")
+	assert.Equal(time.Date(2023, 6, 15, 8, 0, 0, 0, time.UTC).Unix(), completed.Unix(), "Should return the time of the last criterion")
+
+	a.Done = false
+	_, ok = a.CompletedAt()
+	assert.False(ok, "Should not be completed when not done")
+}
+
+func TestStatusEffectGetID(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal("minecraft:speed", StatusEffect{ID: int8(1)}.GetID())
+	assert.Equal("minecraft:darkness", StatusEffect{ID: int32(33)}.GetID())
+	assert.Equal("99", StatusEffect{ID: int8(99)}.GetID(), "Should keep unknown ids")
+	assert.Equal("minecraft:haste", StatusEffect{ID: "minecraft:haste"}.GetID())
+}
+
+func TestPlayerDataKeyCasing(t *testing.T) {
+	tMatrix := map[string]struct {
+		Data       map[string]any
+		Effect     StatusEffect
+		Attributes []Attribute
+	}{
+		"Capitalized": {
+			Data: map[string]any{
+				"ActiveEffects": []map[string]any{
+					{"Id": int8(1), "Amplifier": int8(1), "Duration": int32(200)},
+				},
+				"Attributes": []map[string]any{
+					{
+						"Name": "minecraft:generic.max_health",
+						"Base": 20.0,
+						"Modifiers": []map[string]any{
+							{"Name": "Armor modifier", "Amount": 2.0, "Operation": int32(0)},
+						},
+					},
+				},
+			},
+			Effect: StatusEffect{ID: int8(1), Amplifier: 1, Duration: 200},
+			Attributes: []Attribute{
+				{
+					Name: "minecraft:generic.max_health",
+					Base: 20,
+					Modifiers: []AttributeModifier{
+						{Name: "Armor modifier", Amount: 2, Operation: int32(0)},
+					},
+				},
+			},
+		},
+		"Lowercase": {
+			Data: map[string]any{
+				"active_effects": []map[string]any{
+					{"id": "minecraft:speed", "amplifier": int8(1), "duration": int32(200)},
+				},
+				"attributes": []map[string]any{
+					{
+						"id":   "minecraft:max_health",
+						"base": 20.0,
+						"modifiers": []map[string]any{
+							{"id": "minecraft:armor.chestplate", "amount": 2.0, "operation": "add_value"},
+						},
+					},
+				},
+			},
+			Effect: StatusEffect{ID: "minecraft:speed", Amplifier: 1, Duration: 200},
+			Attributes: []Attribute{
+				{
+					ID:   "minecraft:max_health",
+					Base: 20,
+					Modifiers: []AttributeModifier{
+						{ID: "minecraft:armor.chestplate", Amount: 2, Operation: "add_value"},
+					},
+				},
+			},
+		},
+	}
+
+	for name, tCase := range tMatrix {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			raw, err := nbt.Marshal(tCase.Data)
+			require.NoError(err, "Should encode player data")
+
+			var d MinecraftPlayerData
+			require.NoError(nbt.Unmarshal(raw, &d), "Should decode player data")
+
+			require.Len(d.GetEffects(), 1, "Should decode the effect")
+			assert.Equal(tCase.Effect, d.GetEffects()[0])
+			assert.Equal("minecraft:speed", d.GetEffects()[0].GetID())
+			assert.Equal(tCase.Attributes, d.GetAttributes())
+		})
+	}
+}
+
+func TestLoadLevelData(t *testing.T) {
+	t.Run("1.12", func(t *testing.T) {
+		assert := assert.New(t)
+		require := require.New(t)
+
+		s, err := NewSave("./testdata/1.12")
+		require.NoError(err, "Should create save")
+
+		level, err := s.LoadLevelData()
+		require.NoError(err, "Should load level.dat")
+
+		assert.Equal(int64(270651842), level.Time)
+		require.NotNil(level.DayTime)
+		assert.Equal(int64(270777760), *level.DayTime)
+		require.NotNil(level.Raining)
+		assert.False(*level.Raining)
+		assert.Equal(48228, level.RainTime)
+		assert.Equal(45139, level.ThunderTime)
+		difficulty, ok := level.GetDifficulty()
+		assert.True(ok, "Should have difficulty")
+		assert.Equal(1, difficulty)
+		assert.False(level.IsHardcore())
+		require.NotNil(level.BorderSize)
+		assert.Equal(float64(60000000), *level.BorderSize)
+		assert.Equal(0.2, level.BorderDamagePerBlock)
+		spawn, ok := level.GetSpawn()
+		assert.True(ok, "Should have spawn")
+		assert.Equal([3]int{2, 65, 2}, spawn)
+		assert.Equal(&DragonFight{DragonKilled: true, PreviouslyKilled: true}, level.GetDragonFight(), "Should read dragon fight from the dimension data")
+	})
+	t.Run("1.20", func(t *testing.T) {
+		assert := assert.New(t)
+		require := require.New(t)
+
+		s, err := NewSave("./testdata/1.20")
+		require.NoError(err, "Should create save")
+
+		level, err := s.LoadLevelData()
+		require.NoError(err, "Should load level.dat")
+
+		assert.Equal(int64(339691571), level.Time)
+		require.NotNil(level.DayTime)
+		assert.Equal(int64(296409775), *level.DayTime)
+		require.NotNil(level.Thundering)
+		assert.False(*level.Thundering)
+		assert.Equal(135376, level.RainTime)
+		assert.Equal(45026, level.ThunderTime)
+		difficulty, ok := level.GetDifficulty()
+		assert.True(ok, "Should have difficulty")
+		assert.Equal(3, difficulty)
+		require.NotNil(level.BorderSize)
+		assert.Equal(float64(6528), *level.BorderSize)
+		spawn, ok := level.GetSpawn()
+		assert.True(ok, "Should have spawn")
+		assert.Equal([3]int{13, 69, -6}, spawn)
+		assert.Equal(&DragonFight{}, level.GetDragonFight())
+		assert.Len(level.GameRules, 45)
+		assert.Equal("false", level.GameRules["keepInventory"])
+		assert.Equal("3", level.GameRules["randomTickSpeed"])
+		assert.Len(level.DataPacks.Enabled, 18)
+		assert.Equal([]string{"bundle", "file/BorderAnnounce.zip", "file/afk_display.zip"}, level.DataPacks.Disabled)
+	})
+	t.Run("26", func(t *testing.T) {
+		assert := assert.New(t)
+		require := require.New(t)
+
+		s, err := NewSave("./testdata/26")
+		require.NoError(err, "Should create save")
+
+		level, err := s.LoadLevelData()
+		require.NoError(err, "Should load level.dat")
+
+		assert.Equal(int64(8500426), level.Time)
+		assert.Nil(level.DayTime, "Day time is no longer part of level.dat")
+		assert.Nil(level.Raining, "Weather is no longer part of level.dat")
+		assert.Nil(level.BorderSize, "World border is no longer part of level.dat")
+		difficulty, ok := level.GetDifficulty()
+		assert.True(ok, "Should have difficulty")
+		assert.Equal(3, difficulty)
+		assert.False(level.IsHardcore())
+		spawn, ok := level.GetSpawn()
+		assert.True(ok, "Should have spawn")
+		assert.Equal([3]int{0, 71, 0}, spawn)
+		assert.Nil(level.GetDragonFight())
+		assert.Empty(level.GameRules, "Game rules are no longer part of level.dat")
+		assert.Equal(DataPacks{
+			Enabled:  []string{"vanilla", "file/bukkit", "paper"},
+			Disabled: []string{"minecart_improvements", "redstone_experiments", "trade_rebalance"},
+		}, level.DataPacks)
+	})
+}
+
+func TestGetDimensions(t *testing.T) {
+	t.Run("Legacy", func(t *testing.T) {
+		require := require.New(t)
+
+		path := newTestWorld(t, "1.20")
+		require.NoError(os.MkdirAll(path+REGION_DIR, 0755))
+		require.NoError(os.MkdirAll(path+END_DIR_LEGACY+REGION_DIR, 0755))
+		require.NoError(os.MkdirAll(path+DIMENSIONS_DIR+"/custom/mining"+REGION_DIR, 0755))
+		require.NoError(os.MkdirAll(path+DIMENSIONS_DIR+"/custom/not_generated", 0755))
+
+		s, err := NewSave(path)
+		require.NoError(err, "Should create save")
+
+		expected := []Dimension{
+			{Name: DIMENSION_OVERWORLD, Path: path},
+			{Name: DIMENSION_THE_END, Path: path + END_DIR_LEGACY},
+			{Name: "custom:mining", Path: path + DIMENSIONS_DIR + "/custom/mining"},
+		}
+		require.Equal(expected, s.GetDimensions(), "Should only return generated dimensions")
+	})
+	t.Run("v26", func(t *testing.T) {
+		require := require.New(t)
+
+		path := newTestWorld(t, "26")
+		require.NoError(os.MkdirAll(path+DIMENSIONS_DIR+DIMENSION_DIR_OVERWORLD+REGION_DIR, 0755))
+		require.NoError(os.MkdirAll(path+DIMENSIONS_DIR+DIMENSION_DIR_THE_NETHER+REGION_DIR, 0755))
+		require.NoError(os.MkdirAll(path+DIMENSIONS_DIR+"/custom/mining/deep"+REGION_DIR, 0755))
+
+		s, err := NewSave(path)
+		require.NoError(err, "Should create save")
+
+		expected := []Dimension{
+			{Name: DIMENSION_OVERWORLD, Path: path + DIMENSIONS_DIR + DIMENSION_DIR_OVERWORLD},
+			{Name: DIMENSION_THE_NETHER, Path: path + DIMENSIONS_DIR + DIMENSION_DIR_THE_NETHER},
+			{Name: "custom:mining/deep", Path: path + DIMENSIONS_DIR + "/custom/mining/deep"},
+		}
+		require.Equal(expected, s.GetDimensions(), "Should only return generated dimensions")
+	})
+}
+
+// Copy the given testdata world into a temporary directory, so it can be modified
+func newTestWorld(t *testing.T, name string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+	err := os.CopyFS(path, os.DirFS("testdata/"+name))
+	require.NoError(t, err, "Should copy test world")
+	return path
+}
+
+func intPtr(i int) *int {
+	return &i
+}
+
 func copyFile(src, dst string) error {
 	sourceFileStat, err := os.Stat(src)
 	if err != nil {
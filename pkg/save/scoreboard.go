@@ -0,0 +1,48 @@
+package save
+
+import (
+	"os"
+)
+
+const SCOREBOARD_FILE = "/scoreboard.dat"
+
+type scoreboardFile struct {
+	Data Scoreboard `nbt:"data"`
+}
+
+// The scoreboard of the world, as stored in data/scoreboard.dat
+type Scoreboard struct {
+	Objectives   []ScoreboardObjective `nbt:"Objectives"`
+	PlayerScores []ScoreboardScore     `nbt:"PlayerScores"`
+	Teams        []ScoreboardTeam      `nbt:"Teams"`
+}
+
+type ScoreboardObjective struct {
+	Name         string `nbt:"Name"`
+	CriteriaName string `nbt:"CriteriaName"`
+}
+
+type ScoreboardScore struct {
+	// The score holder, either a player name, an entity uuid or a fake player like "#global"
+	Name      string `nbt:"Name"`
+	Objective string `nbt:"Objective"`
+	Score     int    `nbt:"Score"`
+}
+
+type ScoreboardTeam struct {
+	Name    string   `nbt:"Name"`
+	Players []string `nbt:"Players"`
+}
+
+// Load the scoreboard of the world.
+// Returns an empty scoreboard if the world does not have one yet.
+func (s *Save) LoadScoreboard() (Scoreboard, error) {
+	var data scoreboardFile
+	err := readNBT(s.dataDir+SCOREBOARD_FILE, &data)
+	if os.IsNotExist(err) {
+		return Scoreboard{}, nil
+	} else if err != nil {
+		return Scoreboard{}, err
+	}
+	return data.Data, nil
+}
@@ -0,0 +1,69 @@
+package save
+
+import (
+	"log/slog"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type ScoreboardCollector struct {
+	save     *Save
+	Instance string
+	// Glob patterns of objectives to export, all objectives are exported when empty
+	Include []string
+	// Glob patterns of objectives to skip, takes precedence over Include
+	Exclude []string
+}
+
+var (
+	mcScoreboardScoreDesc      = prometheus.NewDesc("minecraft_scoreboard_score", "Score of the holder in the scoreboard objective", []string{"instance", "objective", "holder"}, nil)
+	mcScoreboardTeamMemberDesc = prometheus.NewDesc("minecraft_scoreboard_team_member_info", "Membership of a player or entity in a scoreboard team", []string{"instance", "team", "member"}, nil)
+)
+
+// Create new instance of collector, returns error if an world directory is not provided
+// Arguments:
+//
+//	path: The path of the minecraft world directory
+//	instance: The instance label to use for the metrics
+func NewScoreboardCollector(path, instance string) (*ScoreboardCollector, error) {
+	save, err := NewSave(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ScoreboardCollector{
+		save:     save,
+		Instance: instance,
+	}, nil
+}
+
+// Implements the Describe function for prometheus.Collector
+func (c *ScoreboardCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- mcScoreboardScoreDesc
+	ch <- mcScoreboardTeamMemberDesc
+}
+
+// Implements the Collect function for prometheus.Collector
+func (c *ScoreboardCollector) Collect(ch chan<- prometheus.Metric) {
+	slog.Debug("Starting collection of scoreboard")
+
+	scoreboard, err := c.save.LoadScoreboard()
+	if err != nil {
+		slog.Error("Failed to load the scoreboard", "err", err)
+		return
+	}
+
+	for _, score := range scoreboard.PlayerScores {
+		if !matchesFilter(score.Objective, c.Include, c.Exclude) {
+			continue
+		}
+		ch <- prometheus.MustNewConstMetric(mcScoreboardScoreDesc, prometheus.GaugeValue, float64(score.Score), c.Instance, score.Objective, score.Name)
+	}
+	for _, team := range scoreboard.Teams {
+		for _, member := range team.Players {
+			ch <- prometheus.MustNewConstMetric(mcScoreboardTeamMemberDesc, prometheus.GaugeValue, 1, c.Instance, team.Name, member)
+		}
+	}
+
+	slog.Debug("Finished collection of scoreboard")
+}
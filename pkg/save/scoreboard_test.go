@@ -0,0 +1,123 @@
+package save
+
+import (
+	"bytes"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Tnze/go-mc/nbt"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Write the data as gzip compressed nbt file, like minecraft does for data/*.dat
+func writeTestNBT(t *testing.T, path string, data interface{}) {
+	t.Helper()
+
+	raw, err := nbt.Marshal(data)
+	require.NoError(t, err, "Should encode nbt")
+
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	_, err = w.Write(raw)
+	require.NoError(t, err, "Should compress nbt")
+	require.NoError(t, w.Close(), "Should compress nbt")
+
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755), "Should create directory")
+	require.NoError(t, os.WriteFile(path, buf.Bytes(), 0644), "Should write nbt file")
+}
+
+func testScoreboardData() map[string]any {
+	return map[string]any{
+		"DataVersion": int32(3465),
+		"data": map[string]any{
+			"Objectives": []map[string]any{
+				{"Name": "kills", "CriteriaName": "playerKillCount"},
+				{"Name": "eco_balance", "CriteriaName": "dummy"},
+			},
+			"PlayerScores": []map[string]any{
+				{"Name": "heathcliff26", "Objective": "kills", "Score": int32(12), "Locked": int8(1)},
+				{"Name": "#global", "Objective": "eco_balance", "Score": int32(-5), "Locked": int8(1)},
+			},
+			"Teams": []map[string]any{
+				{"Name": "red", "Players": []string{"heathcliff26", "Steve"}},
+				{"Name": "blue", "Players": []string{}},
+			},
+		},
+	}
+}
+
+func TestLoadScoreboard(t *testing.T) {
+	t.Run("Legacy", func(t *testing.T) {
+		assert := assert.New(t)
+		require := require.New(t)
+
+		path := newTestWorld(t, "1.20")
+		writeTestNBT(t, path+DATA_DIR+SCOREBOARD_FILE, testScoreboardData())
+
+		s, err := NewSave(path)
+		require.NoError(err, "Should create save")
+
+		scoreboard, err := s.LoadScoreboard()
+		require.NoError(err, "Should load scoreboard")
+
+		assert.Equal([]ScoreboardObjective{{Name: "kills", CriteriaName: "playerKillCount"}, {Name: "eco_balance", CriteriaName: "dummy"}}, scoreboard.Objectives)
+		assert.Equal([]ScoreboardScore{{Name: "heathcliff26", Objective: "kills", Score: 12}, {Name: "#global", Objective: "eco_balance", Score: -5}}, scoreboard.PlayerScores)
+		require.Len(scoreboard.Teams, 2)
+		assert.Equal([]string{"heathcliff26", "Steve"}, scoreboard.Teams[0].Players)
+	})
+	t.Run("Namespaced", func(t *testing.T) {
+		require := require.New(t)
+
+		path := newTestWorld(t, "26")
+		writeTestNBT(t, path+DATA_DIR_NAMESPACED+SCOREBOARD_FILE, testScoreboardData())
+
+		s, err := NewSave(path)
+		require.NoError(err, "Should create save")
+
+		scoreboard, err := s.LoadScoreboard()
+		require.NoError(err, "Should load scoreboard")
+		require.Len(scoreboard.PlayerScores, 2, "Should read scoreboard from data/minecraft")
+	})
+	t.Run("Missing", func(t *testing.T) {
+		require := require.New(t)
+
+		s, err := NewSave("./testdata/1.20")
+		require.NoError(err, "Should create save")
+
+		scoreboard, err := s.LoadScoreboard()
+		require.NoError(err, "Should not fail without a scoreboard")
+		require.Empty(scoreboard.PlayerScores)
+	})
+}
+
+func TestScoreboardCollector(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	c, err := NewScoreboardCollector("not-a-path", "test-instance")
+	assert.Error(err, "Should not create collector with invalid path")
+	assert.Nil(c, "Collector should be nil on error")
+
+	path := newTestWorld(t, "1.20")
+	writeTestNBT(t, path+DATA_DIR+SCOREBOARD_FILE, testScoreboardData())
+
+	c, err = NewScoreboardCollector(path, "test-instance")
+	require.NoError(err, "Should create collector")
+	c.Exclude = []string{"eco_*"}
+
+	reg := prometheus.NewPedanticRegistry()
+	require.NoError(reg.Register(c), "Should register collector")
+
+	families, err := reg.Gather()
+	require.NoError(err, "Collected metrics should match the description")
+
+	result := make(map[string]int, len(families))
+	for _, family := range families {
+		result[family.GetName()] = len(family.GetMetric())
+	}
+	assert.Equal(map[string]int{"minecraft_scoreboard_score": 1, "minecraft_scoreboard_team_member_info": 2}, result, "Should skip excluded objectives")
+}
@@ -3,13 +3,128 @@ package save
 import (
 	"encoding/json/jsontext"
 	"encoding/json/v2"
+	"fmt"
+	"slices"
+	"time"
 )
 
 type MinecraftLevelDat struct {
-	Data struct {
-		Version        MinecraftVersion `nbt:"Version"`
-		StorageVersion int              `nbt:"version"`
-	} `nbt:"Data"`
+	Data LevelData `nbt:"Data"`
+}
+
+// The content of level.dat.
+// Fields that have been moved out of level.dat in newer versions are pointers,
+// so their absence can be detected.
+type LevelData struct {
+	Version        MinecraftVersion `nbt:"Version"`
+	StorageVersion int              `nbt:"version"`
+
+	Time    int64  `nbt:"Time"`
+	DayTime *int64 `nbt:"DayTime"`
+
+	Raining          *bool `nbt:"raining"`
+	RainTime         int   `nbt:"rainTime"`
+	Thundering       *bool `nbt:"thundering"`
+	ThunderTime      int   `nbt:"thunderTime"`
+	ClearWeatherTime int   `nbt:"clearWeatherTime"`
+
+	Difficulty         *int8               `nbt:"Difficulty"`
+	Hardcore           bool                `nbt:"hardcore"`
+	DifficultySettings *DifficultySettings `nbt:"difficulty_settings"`
+
+	BorderCenterX        float64  `nbt:"BorderCenterX"`
+	BorderCenterZ        float64  `nbt:"BorderCenterZ"`
+	BorderSize           *float64 `nbt:"BorderSize"`
+	BorderDamagePerBlock float64  `nbt:"BorderDamagePerBlock"`
+
+	SpawnX *int       `nbt:"SpawnX"`
+	SpawnY int        `nbt:"SpawnY"`
+	SpawnZ int        `nbt:"SpawnZ"`
+	Spawn  *GlobalPos `nbt:"spawn"`
+
+	DragonFight   *DragonFight `nbt:"DragonFight"`
+	DimensionData map[string]struct {
+		DragonFight *DragonFight `nbt:"DragonFight"`
+	} `nbt:"DimensionData"`
+
+	// Older versions save all values as string, newer versions use typed values
+	GameRules map[string]any `nbt:"GameRules"`
+	DataPacks DataPacks      `nbt:"DataPacks"`
+}
+
+type DataPacks struct {
+	Enabled  []string `nbt:"Enabled"`
+	Disabled []string `nbt:"Disabled"`
+}
+
+// Difficulty settings, since 26.1
+type DifficultySettings struct {
+	Difficulty string `nbt:"difficulty"`
+	Hardcore   bool   `nbt:"hardcore"`
+	Locked     bool   `nbt:"locked"`
+}
+
+// Block position in a dimension, used for the world spawn since 26.1
+type GlobalPos struct {
+	Pos       []int32 `nbt:"pos"`
+	Dimension string  `nbt:"dimension"`
+}
+
+type DragonFight struct {
+	DragonKilled     bool `nbt:"DragonKilled"`
+	PreviouslyKilled bool `nbt:"PreviouslyKilled"`
+}
+
+// Return the difficulty as number (0 = peaceful, 1 = easy, 2 = normal, 3 = hard).
+// Returns false if the difficulty is not known.
+func (d LevelData) GetDifficulty() (int, bool) {
+	if d.DifficultySettings != nil {
+		switch d.DifficultySettings.Difficulty {
+		case "peaceful":
+			return 0, true
+		case "easy":
+			return 1, true
+		case "normal":
+			return 2, true
+		case "hard":
+			return 3, true
+		default:
+			return 0, false
+		}
+	}
+	if d.Difficulty != nil {
+		return int(*d.Difficulty), true
+	}
+	return 0, false
+}
+
+// Return if the world is in hardcore mode
+func (d LevelData) IsHardcore() bool {
+	if d.DifficultySettings != nil {
+		return d.DifficultySettings.Hardcore
+	}
+	return d.Hardcore
+}
+
+// Return the world spawn as x, y, z.
+// Returns false if the spawn is not contained in level.dat.
+func (d LevelData) GetSpawn() ([3]int, bool) {
+	if d.Spawn != nil && len(d.Spawn.Pos) == 3 {
+		return [3]int{int(d.Spawn.Pos[0]), int(d.Spawn.Pos[1]), int(d.Spawn.Pos[2])}, true
+	}
+	if d.SpawnX != nil {
+		return [3]int{*d.SpawnX, d.SpawnY, d.SpawnZ}, true
+	}
+	return [3]int{}, false
+}
+
+// Return the state of the ender dragon fight.
+// Prior to 1.16 it is saved under the dimension data of the end.
+func (d LevelData) GetDragonFight() *DragonFight {
+	if d.DragonFight != nil {
+		return d.DragonFight
+	}
+	return d.DimensionData["1"].DragonFight
 }
 
 type MinecraftVersion struct {
@@ -48,8 +163,33 @@ func (a *MinecraftAdvancements) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// Format of the timestamps in the advancement criteria
+const ADVANCEMENT_TIME_FORMAT = "2006-01-02 15:04:05 -0700"
+
 type Advancement struct {
 	Done bool `json:"done"`
+	// Time each criterion has been met
+	Criteria map[string]string `json:"criteria"`
+}
+
+// Return the time the advancement was completed, which is the time the last criterion was met.
+// Returns false if the advancement is not done.
+func (a Advancement) CompletedAt() (time.Time, bool) {
+	if !a.Done {
+		return time.Time{}, false
+	}
+
+	var completed time.Time
+	for _, value := range a.Criteria {
+		t, err := time.Parse(ADVANCEMENT_TIME_FORMAT, value)
+		if err != nil {
+			continue
+		}
+		if t.After(completed) {
+			completed = t
+		}
+	}
+	return completed, !completed.IsZero()
 }
 
 type MinecraftStats struct {
@@ -62,7 +202,55 @@ type Stats struct {
 	PickedUp     map[string]int `json:"minecraft:picked_up"`
 	Killed       map[string]int `json:"minecraft:killed"`
 	KilledBy     map[string]int `json:"minecraft:killed_by"`
+	Used         map[string]int `json:"minecraft:used"`
+	Broken       map[string]int `json:"minecraft:broken"`
+	Dropped      map[string]int `json:"minecraft:dropped"`
 	Custom       CustomStats    `json:"minecraft:custom"`
+
+	// Categories that are not mapped above, e.g. added by mods
+	Other map[string]map[string]int `json:"-"`
+}
+
+// Stat categories that are mapped to fields of Stats
+var statCategories = map[string]bool{
+	"minecraft:crafted":   true,
+	"minecraft:mined":     true,
+	"minecraft:picked_up": true,
+	"minecraft:killed":    true,
+	"minecraft:killed_by": true,
+	"minecraft:used":      true,
+	"minecraft:broken":    true,
+	"minecraft:dropped":   true,
+	"minecraft:custom":    true,
+}
+
+// Implements Unmarshal, allows unknown categories to be saved in Other
+func (s *Stats) UnmarshalJSON(data []byte) error {
+	// Use a type without the UnmarshalJSON method to decode the known categories
+	type knownStats Stats
+	var known knownStats
+	if err := json.Unmarshal(data, &known); err != nil {
+		return err
+	}
+
+	var categories map[string]jsontext.Value
+	if err := json.Unmarshal(data, &categories); err != nil {
+		return err
+	}
+
+	*s = Stats(known)
+	s.Other = make(map[string]map[string]int)
+	for category, value := range categories {
+		if statCategories[category] {
+			continue
+		}
+		var stats map[string]int
+		if err := json.Unmarshal(value, &stats); err != nil {
+			return err
+		}
+		s.Other[category] = stats
+	}
+	return nil
 }
 
 func NewStats() Stats {
@@ -72,6 +260,10 @@ func NewStats() Stats {
 		PickedUp:     make(map[string]int),
 		Killed:       make(map[string]int),
 		KilledBy:     make(map[string]int),
+		Used:         make(map[string]int),
+		Broken:       make(map[string]int),
+		Dropped:      make(map[string]int),
+		Other:        make(map[string]map[string]int),
 		Custom: CustomStats{
 			Custom: make(map[string]int),
 		},
@@ -153,4 +345,239 @@ type MinecraftPlayerData struct {
 	Score     int     `nbt:"Score"`
 	Health    float64 `nbt:"Health"`
 	FoodLevel int     `nbt:"foodLevel"`
+
+	GameMode         int     `nbt:"playerGameType"`
+	Air              int     `nbt:"Air"`
+	Fire             int     `nbt:"Fire"`
+	AbsorptionAmount float64 `nbt:"AbsorptionAmount"`
+	FoodSaturation   float64 `nbt:"foodSaturationLevel"`
+	XPProgress       float64 `nbt:"XpP"`
+
+	// Renamed to active_effects in 1.20.2
+	ActiveEffects   []StatusEffect `nbt:"ActiveEffects"`
+	ActiveEffectsV2 []StatusEffect `nbt:"active_effects"`
+	// Renamed to attributes in 1.20.5
+	Attributes   []Attribute `nbt:"Attributes"`
+	AttributesV2 []Attribute `nbt:"attributes"`
+
+	Pos      []float64 `nbt:"Pos"`
+	Rotation []float32 `nbt:"Rotation"`
+	// Saved as number prior to 1.16, as namespaced id afterwards
+	Dimension any `nbt:"Dimension"`
+
+	SpawnX         *int   `nbt:"SpawnX"`
+	SpawnY         int    `nbt:"SpawnY"`
+	SpawnZ         int    `nbt:"SpawnZ"`
+	SpawnDimension string `nbt:"SpawnDimension"`
+	// Replaces SpawnX/Y/Z and SpawnDimension since 26.1
+	Respawn *GlobalPos `nbt:"respawn"`
+
+	// Since 1.19
+	LastDeathLocation *GlobalPos `nbt:"LastDeathLocation"`
+
+	Inventory  []ItemStack `nbt:"Inventory"`
+	EnderItems []ItemStack `nbt:"EnderItems"`
+	// Armor and offhand, moved out of the inventory in 1.21.5
+	Equipment map[string]ItemStack `nbt:"equipment"`
+}
+
+// Item stack as saved in inventories
+type ItemStack struct {
+	ID string `nbt:"id"`
+	// Saved as byte prior to 1.20.5
+	Count int `nbt:"Count"`
+	// Replaces Count since 1.20.5
+	CountV2 *int `nbt:"count"`
+
+	// Contains the items of shulker boxes prior to 1.20.5
+	Tag *ItemTag `nbt:"tag"`
+	// Replaces Tag since 1.20.5
+	Components *ItemComponents `nbt:"components"`
+}
+
+type ItemTag struct {
+	BlockEntityTag *struct {
+		Items []ItemStack `nbt:"Items"`
+	} `nbt:"BlockEntityTag"`
+}
+
+type ItemComponents struct {
+	Container      []ContainerSlot `nbt:"minecraft:container"`
+	BundleContents []ItemStack     `nbt:"minecraft:bundle_contents"`
+}
+
+type ContainerSlot struct {
+	Slot int       `nbt:"slot"`
+	Item ItemStack `nbt:"item"`
+}
+
+// The keys are capitalized prior to 1.20.2 (Id, Amplifier, Duration) and lowercase afterwards.
+// Both are decoded by the same fields, as the nbt decoder falls back to case-insensitive matching of the keys.
+type StatusEffect struct {
+	// Saved as number prior to 1.20.2, as namespaced id afterwards
+	ID        any `nbt:"id"`
+	Amplifier int `nbt:"amplifier"`
+	// Remaining duration in ticks, -1 for infinite effects
+	Duration int `nbt:"duration"`
+}
+
+// Return the namespaced id of the effect
+func (e StatusEffect) GetID() string {
+	switch id := e.ID.(type) {
+	case string:
+		return id
+	case int8:
+		return legacyEffect(int(id))
+	case int32:
+		return legacyEffect(int(id))
+	default:
+		return fmt.Sprint(id)
+	}
+}
+
+// The keys are capitalized prior to 1.20.5 (Name, Base, Modifiers) and lowercase afterwards.
+// Base and Modifiers rely on the case-insensitive matching of the nbt decoder to cover both.
+type Attribute struct {
+	// Replaced by ID in 1.20.5
+	Name      string              `nbt:"Name"`
+	ID        string              `nbt:"id"`
+	Base      float64             `nbt:"Base"`
+	Modifiers []AttributeModifier `nbt:"Modifiers"`
+}
+
+// Return the id of the attribute
+func (a Attribute) GetID() string {
+	if a.ID != "" {
+		return a.ID
+	}
+	return a.Name
+}
+
+// The keys are capitalized prior to 1.20.5 (Name, Amount, Operation) and lowercase afterwards.
+// Amount and Operation rely on the case-insensitive matching of the nbt decoder to cover both.
+type AttributeModifier struct {
+	// Replaced by ID in 1.20.5
+	Name   string  `nbt:"Name"`
+	ID     string  `nbt:"id"`
+	Amount float64 `nbt:"Amount"`
+	// Saved as number prior to 1.20.5
+	Operation any `nbt:"Operation"`
+}
+
+// Return the id of the modifier
+func (m AttributeModifier) GetID() string {
+	if m.ID != "" {
+		return m.ID
+	}
+	return m.Name
+}
+
+// Return the name of the operation used by the modifier
+func (m AttributeModifier) GetOperation() string {
+	switch op := m.Operation.(type) {
+	case string:
+		return op
+	case int32:
+		switch op {
+		case 0:
+			return "add_value"
+		case 1:
+			return "add_multiplied_base"
+		case 2:
+			return "add_multiplied_total"
+		}
+	}
+	return fmt.Sprint(m.Operation)
+}
+
+// Return the number of items in the stack.
+// Newer versions omit the count for single items.
+func (i ItemStack) GetCount() int {
+	if i.CountV2 != nil {
+		return *i.CountV2
+	}
+	if i.Count > 0 {
+		return i.Count
+	}
+	return 1
+}
+
+// Return the items stored inside of the item, e.g. the contents of a shulker box or bundle.
+// Does not include the contents of further nested items.
+func (i ItemStack) NestedItems() []ItemStack {
+	var items []ItemStack
+	if i.Tag != nil && i.Tag.BlockEntityTag != nil {
+		items = append(items, i.Tag.BlockEntityTag.Items...)
+	}
+	if i.Components != nil {
+		for _, slot := range i.Components.Container {
+			items = append(items, slot.Item)
+		}
+		items = append(items, i.Components.BundleContents...)
+	}
+	return items
+}
+
+// Return the dimension the player is currently in.
+// Converts the numeric ids used prior to 1.16.
+func (d MinecraftPlayerData) GetDimension() string {
+	switch dim := d.Dimension.(type) {
+	case string:
+		return dim
+	case int32:
+		return legacyDimension(dim)
+	default:
+		return DIMENSION_OVERWORLD
+	}
+}
+
+// Return the active status effects of the player
+func (d MinecraftPlayerData) GetEffects() []StatusEffect {
+	return slices.Concat(d.ActiveEffects, d.ActiveEffectsV2)
+}
+
+// Return the attributes of the player
+func (d MinecraftPlayerData) GetAttributes() []Attribute {
+	return slices.Concat(d.Attributes, d.AttributesV2)
+}
+
+// Return the number of items in the inventory of the player by item id, including armor and offhand.
+func (d MinecraftPlayerData) InventoryItems() map[string]int {
+	items := countItems(d.Inventory)
+	for _, item := range d.Equipment {
+		items[item.ID] += item.GetCount()
+	}
+	return items
+}
+
+// Return the number of items in the ender chest of the player by item id.
+func (d MinecraftPlayerData) EnderChestItems() map[string]int {
+	return countItems(d.EnderItems)
+}
+
+// Return the position of the player.
+func (d MinecraftPlayerData) GetPosition() ([3]float64, bool) {
+	if len(d.Pos) != 3 {
+		return [3]float64{}, false
+	}
+	return [3]float64{d.Pos[0], d.Pos[1], d.Pos[2]}, true
+}
+
+// Return the respawn point of the player, returns false if the player has none.
+// Prior to 1.16 the respawn point is always in the overworld.
+func (d MinecraftPlayerData) GetSpawn() (GlobalPos, bool) {
+	if d.Respawn != nil && len(d.Respawn.Pos) == 3 {
+		return *d.Respawn, true
+	}
+	if d.SpawnX != nil {
+		dimension := d.SpawnDimension
+		if dimension == "" {
+			dimension = DIMENSION_OVERWORLD
+		}
+		return GlobalPos{
+			Pos:       []int32{int32(*d.SpawnX), int32(d.SpawnY), int32(d.SpawnZ)},
+			Dimension: dimension,
+		}, true
+	}
+	return GlobalPos{}, false
 }
@@ -4,8 +4,14 @@ import (
 	"compress/gzip"
 	"encoding/json/v2"
 	"io"
+	"io/fs"
 	"os"
+	"path"
 	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/Tnze/go-mc/nbt"
 	"github.com/prometheus/client_golang/prometheus"
@@ -20,17 +26,83 @@ func isDirectory(path string) bool {
 	return fileInfo.IsDir()
 }
 
-// Count the total number of earned advancements
+// Search the given directory for dimensions, which are stored as <namespace>/<name>.
+// A directory is considered a dimension when it contains a region folder.
+func findCustomDimensions(dir string) []Dimension {
+	if !isDirectory(dir) {
+		return nil
+	}
+
+	var dimensions []Dimension
+	_ = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || !d.IsDir() || path == dir {
+			return nil
+		}
+		if !isDirectory(filepath.Join(path, REGION_DIR)) {
+			return nil
+		}
+
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return fs.SkipDir
+		}
+		namespace, name, ok := strings.Cut(filepath.ToSlash(rel), "/")
+		if ok {
+			dimensions = append(dimensions, Dimension{
+				Name: namespace + ":" + name,
+				Path: path,
+			})
+		}
+		return fs.SkipDir
+	})
+	return dimensions
+}
+
+// Count the total number of earned advancements, excluding recipe unlocks
 func countAdvancements(advancements map[string]Advancement) uint {
 	var i uint
-	for _, value := range advancements {
-		if value.Done {
+	for key, value := range advancements {
+		if value.Done && !isRecipe(key) {
 			i++
 		}
 	}
 	return i
 }
 
+// Count the recipes the player has unlocked
+func countRecipes(advancements map[string]Advancement) uint {
+	var i uint
+	for key, value := range advancements {
+		if value.Done && isRecipe(key) {
+			i++
+		}
+	}
+	return i
+}
+
+// Recipe unlocks are saved as advancements under recipes/
+func isRecipe(advancement string) bool {
+	_, path, found := strings.Cut(advancement, ":")
+	if !found {
+		path = advancement
+	}
+	return strings.HasPrefix(path, "recipes/")
+}
+
+// Return the time the player completed the last advancement, ignoring recipes
+func lastAdvancementTime(advancements map[string]Advancement) (time.Time, bool) {
+	var last time.Time
+	for key, value := range advancements {
+		if isRecipe(key) {
+			continue
+		}
+		if t, ok := value.CompletedAt(); ok && t.After(last) {
+			last = t
+		}
+	}
+	return last, !last.IsZero()
+}
+
 // Read a nbt file and parse it to the given struct.
 // Assumes the file is gzip compressed.
 func readNBT(path string, target interface{}) error {
@@ -85,6 +157,138 @@ func countTotal(values map[string]int) int {
 	return total
 }
 
+// Sum up the item stacks by item id
+func countItems(items []ItemStack) map[string]int {
+	result := make(map[string]int, len(items))
+	for _, item := range items {
+		result[item.ID] += item.GetCount()
+	}
+	return result
+}
+
+// Return the namespace of the id, defaults to minecraft
+func statNamespace(id string) string {
+	namespace, _, found := strings.Cut(id, ":")
+	if !found {
+		return "minecraft"
+	}
+	return namespace
+}
+
+// Add the default minecraft namespace to ids without one
+func namespacedID(id string) string {
+	if strings.Contains(id, ":") {
+		return id
+	}
+	return "minecraft:" + id
+}
+
+// Add the default namespace to all ids and remove duplicates, e.g. "diamond" and "minecraft:diamond"
+func namespacedIDs(ids []string) []string {
+	result := make([]string, 0, len(ids))
+	for _, id := range ids {
+		id = namespacedID(id)
+		if !slices.Contains(result, id) {
+			result = append(result, id)
+		}
+	}
+	return result
+}
+
+// Check if the name matches one of the include patterns and none of the exclude patterns.
+// Patterns use glob syntax, an empty include list matches everything.
+// Malformed patterns never match, they are rejected when loading the config.
+func matchesFilter(name string, include, exclude []string) bool {
+	for _, pattern := range exclude {
+		if ok, _ := path.Match(pattern, name); ok {
+			return false
+		}
+	}
+	if len(include) == 0 {
+		return true
+	}
+	for _, pattern := range include {
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// Convert the value of a game rule to a number.
+// Returns false if the value is neither a boolean nor a number.
+func parseGameRule(value any) (float64, bool) {
+	switch v := value.(type) {
+	case string:
+		switch v {
+		case "true":
+			return 1, true
+		case "false":
+			return 0, true
+		}
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return 0, false
+		}
+		return f, true
+	case int8:
+		return float64(v), true
+	case int16:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case float32:
+		return float64(v), true
+	case float64:
+		return v, true
+	default:
+		return 0, false
+	}
+}
+
+// Convert the numeric dimension id used prior to 1.16 into the namespaced id.
+// Modded dimensions have no namespaced id, so the number is returned as is.
+func legacyDimension(id int32) string {
+	switch id {
+	case 0:
+		return DIMENSION_OVERWORLD
+	case -1:
+		return DIMENSION_THE_NETHER
+	case 1:
+		return DIMENSION_THE_END
+	default:
+		return strconv.Itoa(int(id))
+	}
+}
+
+// Numeric ids of status effects used prior to 1.20.2
+var legacyEffects = []string{
+	"speed", "slowness", "haste", "mining_fatigue", "strength", "instant_health", "instant_damage", "jump_boost",
+	"nausea", "regeneration", "resistance", "fire_resistance", "water_breathing", "invisibility", "blindness",
+	"night_vision", "hunger", "weakness", "poison", "wither", "health_boost", "absorption", "saturation", "glowing",
+	"levitation", "luck", "unluck", "slow_falling", "conduit_power", "dolphins_grace", "bad_omen",
+	"hero_of_the_village", "darkness",
+}
+
+// Convert the numeric id of a status effect into the namespaced id.
+// Unknown ids, e.g. from mods, are returned as is.
+func legacyEffect(id int) string {
+	if id < 1 || id > len(legacyEffects) {
+		return strconv.Itoa(id)
+	}
+	return "minecraft:" + legacyEffects[id-1]
+}
+
+// Convert a bool to a metric value
+func boolToFloat(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
 // Convert a given map to metrics
 func mapToMetrics(ch chan<- prometheus.Metric, desc *prometheus.Desc, values map[string]int, labels []string) {
 	for k, v := range values {
@@ -92,12 +296,28 @@ func mapToMetrics(ch chan<- prometheus.Metric, desc *prometheus.Desc, values map
 	}
 }
 
+// Create a metric for each axis of the position, with the dimension as additional label
+func globalPosToMetrics(ch chan<- prometheus.Metric, desc *prometheus.Desc, pos GlobalPos, labels []string) {
+	for i, axis := range []string{"x", "y", "z"} {
+		ch <- prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, float64(pos.Pos[i]), append(labels, pos.Dimension, axis)...)
+	}
+}
+
 // Return the Minecraft Version of the save from level.dat
 func getSaveVersion(path string) (MinecraftVersion, error) {
+	data, err := readLevelDat(path)
+	if err != nil {
+		return MinecraftVersion{}, err
+	}
+	return data.Version, nil
+}
+
+// Read the level.dat of the world
+func readLevelDat(path string) (LevelData, error) {
 	var data MinecraftLevelDat
 	err := readNBT(filepath.Join(path, "level.dat"), &data)
 	if err != nil {
-		return MinecraftVersion{}, err
+		return LevelData{}, err
 	}
-	return data.Data.Version, nil
+	return data.Data, nil
 }
@@ -0,0 +1,56 @@
+package save
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseGameRule(t *testing.T) {
+	tMatrix := []struct {
+		Name   string
+		Value  any
+		Result float64
+		Ok     bool
+	}{
+		{"StringTrue", "true", 1, true},
+		{"StringFalse", "false", 0, true},
+		{"StringNumber", "3", 3, true},
+		{"String", "something", 0, false},
+		{"Byte", int8(1), 1, true},
+		{"Int", int32(24), 24, true},
+		{"Compound", map[string]any{}, 0, false},
+	}
+
+	for _, tCase := range tMatrix {
+		t.Run(tCase.Name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			result, ok := parseGameRule(tCase.Value)
+			assert.Equal(tCase.Ok, ok)
+			assert.Equal(tCase.Result, result)
+		})
+	}
+}
+
+func TestMatchesFilter(t *testing.T) {
+	tMatrix := []struct {
+		Name             string
+		Value            string
+		Include, Exclude []string
+		Result           bool
+	}{
+		{"NoFilter", "kills", nil, nil, true},
+		{"Included", "kills", []string{"kills"}, nil, true},
+		{"NotIncluded", "kills", []string{"deaths"}, nil, false},
+		{"Glob", "eco_balance", []string{"eco_*"}, nil, true},
+		{"Excluded", "eco_balance", nil, []string{"eco_*"}, false},
+		{"ExcludeTakesPrecedence", "eco_balance", []string{"eco_*"}, []string{"eco_balance"}, false},
+	}
+
+	for _, tCase := range tMatrix {
+		t.Run(tCase.Name, func(t *testing.T) {
+			assert.Equal(t, tCase.Result, matchesFilter(tCase.Value, tCase.Include, tCase.Exclude))
+		})
+	}
+}
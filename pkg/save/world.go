@@ -0,0 +1,103 @@
+package save
+
+import (
+	"log/slog"
+	"sync"
+)
+
+// Upper bounds of the buckets for the compressed chunk size histogram
+var chunkSizeBuckets = []float64{1024, 2048, 4096, 8192, 16384, 32768, 65536, 131072, 262144, 1048576}
+
+type DimensionStats struct {
+	Dimension   string
+	RegionFiles int
+	Chunks      int
+
+	// Cumulative histogram of the compressed chunk sizes in bytes
+	ChunkSizeBuckets map[float64]uint64
+	ChunkSizeSum     float64
+}
+
+// Chunk statistics of a single region file
+type regionStats struct {
+	// Compressed size of each readable chunk in bytes
+	chunkSizes []int
+}
+
+// Caches the statistics of the region files, so only modified files are read again
+type worldStatsCache struct {
+	lock    sync.Mutex
+	regions *regionCache[regionStats]
+}
+
+func newWorldStatsCache() *worldStatsCache {
+	return &worldStatsCache{
+		regions: newRegionCache[regionStats](),
+	}
+}
+
+// Load the chunk statistics for all generated dimensions of the save
+func (s *Save) LoadWorldStats() ([]DimensionStats, error) {
+	s.worldStats.lock.Lock()
+	defer s.worldStats.lock.Unlock()
+
+	dimensions := s.GetDimensions()
+	stats := make([]DimensionStats, 0, len(dimensions))
+	for _, dim := range dimensions {
+		stat, err := s.loadDimensionStats(dim)
+		if err != nil {
+			return nil, err
+		}
+		stats = append(stats, stat)
+	}
+	s.worldStats.regions.prune()
+	return stats, nil
+}
+
+// Count the chunks of all region files of the dimension
+func (s *Save) loadDimensionStats(dim Dimension) (DimensionStats, error) {
+	stats := DimensionStats{
+		Dimension:        dim.Name,
+		ChunkSizeBuckets: make(map[float64]uint64, len(chunkSizeBuckets)),
+	}
+	for _, bucket := range chunkSizeBuckets {
+		stats.ChunkSizeBuckets[bucket] = 0
+	}
+
+	paths, err := listRegionFiles(dim.Path + REGION_DIR)
+	if err != nil {
+		return DimensionStats{}, err
+	}
+	regions, _, err := s.worldStats.regions.getAll(paths, readRegionStats)
+	if err != nil {
+		return DimensionStats{}, err
+	}
+
+	for _, region := range regions {
+		stats.RegionFiles++
+		for _, size := range region.chunkSizes {
+			stats.Chunks++
+			stats.ChunkSizeSum += float64(size)
+			for _, bucket := range chunkSizeBuckets {
+				if float64(size) <= bucket {
+					stats.ChunkSizeBuckets[bucket]++
+				}
+			}
+		}
+	}
+
+	return stats, nil
+}
+
+// Read the compressed size of all readable chunks of the region file.
+// Only the header and the chunk lengths are read, so the chunks are not loaded into memory.
+func readRegionStats(path string) (regionStats, error) {
+	sizes, chunkErrs, err := ReadChunkSizes(path)
+	if err != nil {
+		return regionStats{}, err
+	}
+	for _, err := range chunkErrs {
+		slog.Warn("Skipping unreadable chunk", "err", err)
+	}
+	return regionStats{chunkSizes: sizes}, nil
+}
@@ -0,0 +1,176 @@
+package save
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Collects the metrics of a single world from its region files and level.dat.
+// Does not require player data, so it can be used for the additional worlds of bukkit based servers.
+type WorldCollector struct {
+	save     *Save
+	Instance string
+}
+
+var (
+	mcWorldRegionFilesDesc = prometheus.NewDesc("minecraft_world_region_files", "Number of region files of a dimension", worldVariableLabels, nil)
+	mcWorldChunksDesc      = prometheus.NewDesc("minecraft_world_chunks_generated", "Number of generated chunks of a dimension", worldVariableLabels, nil)
+	mcWorldChunkSizeDesc   = prometheus.NewDesc("minecraft_world_chunk_size_bytes", "Compressed size of the chunks of a dimension", worldVariableLabels, nil)
+
+	mcWorldGameTimeDesc               = prometheus.NewDesc("minecraft_world_game_time_ticks", "Total number of ticks the world has been running", levelVariableLabels, nil)
+	mcWorldDayTimeDesc                = prometheus.NewDesc("minecraft_world_day_time_ticks", "Time of day in ticks, keeps counting up across days", levelVariableLabels, nil)
+	mcWorldRainingDesc                = prometheus.NewDesc("minecraft_world_raining", "Indicates if it is currently raining", levelVariableLabels, nil)
+	mcWorldRainTimeDesc               = prometheus.NewDesc("minecraft_world_rain_time_ticks", "Ticks until rain starts or stops", levelVariableLabels, nil)
+	mcWorldThunderingDesc             = prometheus.NewDesc("minecraft_world_thundering", "Indicates if it is currently thundering", levelVariableLabels, nil)
+	mcWorldThunderTimeDesc            = prometheus.NewDesc("minecraft_world_thunder_time_ticks", "Ticks until thunder starts or stops", levelVariableLabels, nil)
+	mcWorldClearWeatherTimeDesc       = prometheus.NewDesc("minecraft_world_clear_weather_time_ticks", "Ticks of clear weather remaining, set by the weather command", levelVariableLabels, nil)
+	mcWorldDifficultyDesc             = prometheus.NewDesc("minecraft_world_difficulty", "Difficulty of the world (0 = peaceful, 1 = easy, 2 = normal, 3 = hard)", levelVariableLabels, nil)
+	mcWorldHardcoreDesc               = prometheus.NewDesc("minecraft_world_hardcore", "Indicates if the world is in hardcore mode", levelVariableLabels, nil)
+	mcWorldBorderCenterDesc           = prometheus.NewDesc("minecraft_world_border_center", "Center of the world border", append(levelVariableLabels, "axis"), nil)
+	mcWorldBorderSizeDesc             = prometheus.NewDesc("minecraft_world_border_size", "Diameter of the world border in blocks", levelVariableLabels, nil)
+	mcWorldBorderDamageDesc           = prometheus.NewDesc("minecraft_world_border_damage_per_block", "Damage per block a player takes when outside of the world border", levelVariableLabels, nil)
+	mcWorldSpawnDesc                  = prometheus.NewDesc("minecraft_world_spawn_position", "Position of the world spawn", append(levelVariableLabels, "axis"), nil)
+	mcWorldDragonKilledDesc           = prometheus.NewDesc("minecraft_world_dragon_killed", "Indicates if the current ender dragon has been killed", levelVariableLabels, nil)
+	mcWorldDragonPreviouslyKilledDesc = prometheus.NewDesc("minecraft_world_dragon_previously_killed", "Indicates if the ender dragon has ever been killed", levelVariableLabels, nil)
+
+	mcWorldGameRuleDesc     = prometheus.NewDesc("minecraft_world_gamerule", "Value of a game rule, booleans are converted to 0 and 1", append(levelVariableLabels, "rule"), nil)
+	mcWorldGameRuleInfoDesc = prometheus.NewDesc("minecraft_world_gamerule_info", "Value of a game rule that is neither a boolean nor a number. Value is always 1", append(levelVariableLabels, "rule", "value"), nil)
+	mcWorldDataPackDesc     = prometheus.NewDesc("minecraft_world_datapack_info", "Data packs of the world and if they are enabled or disabled. Value is always 1", append(levelVariableLabels, "datapack", "status"), nil)
+)
+
+// Create new instance of collector, returns error if an world directory is not provided
+// Arguments:
+//
+//	path: The path of the minecraft world directory
+//	instance: The instance label to use for the metrics
+func NewWorldCollector(path, instance string) (*WorldCollector, error) {
+	save, err := NewWorldSave(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return &WorldCollector{
+		save:     save,
+		Instance: instance,
+	}, nil
+}
+
+// Implements the Describe function for prometheus.Collector
+func (c *WorldCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- mcWorldRegionFilesDesc
+	ch <- mcWorldChunksDesc
+	ch <- mcWorldChunkSizeDesc
+
+	ch <- mcWorldGameTimeDesc
+	ch <- mcWorldDayTimeDesc
+	ch <- mcWorldRainingDesc
+	ch <- mcWorldRainTimeDesc
+	ch <- mcWorldThunderingDesc
+	ch <- mcWorldThunderTimeDesc
+	ch <- mcWorldClearWeatherTimeDesc
+	ch <- mcWorldDifficultyDesc
+	ch <- mcWorldHardcoreDesc
+	ch <- mcWorldBorderCenterDesc
+	ch <- mcWorldBorderSizeDesc
+	ch <- mcWorldBorderDamageDesc
+	ch <- mcWorldSpawnDesc
+	ch <- mcWorldDragonKilledDesc
+	ch <- mcWorldDragonPreviouslyKilledDesc
+
+	ch <- mcWorldGameRuleDesc
+	ch <- mcWorldGameRuleInfoDesc
+	ch <- mcWorldDataPackDesc
+}
+
+// Implements the Collect function for prometheus.Collector
+func (c *WorldCollector) Collect(ch chan<- prometheus.Metric) {
+	slog.Debug("Starting collection of world metrics")
+
+	c.collectWorldStats(ch)
+	c.collectLevelData(ch)
+
+	slog.Debug("Finished collection of world metrics")
+}
+
+// Collect the chunk statistics of all dimensions
+func (c *WorldCollector) collectWorldStats(ch chan<- prometheus.Metric) {
+	worldStats, err := c.save.LoadWorldStats()
+	if err != nil {
+		slog.Error("Failed to load world stats", "err", err)
+		return
+	}
+
+	for _, stat := range worldStats {
+		labels := []string{c.Instance, stat.Dimension}
+		ch <- prometheus.MustNewConstMetric(mcWorldRegionFilesDesc, prometheus.GaugeValue, float64(stat.RegionFiles), labels...)
+		ch <- prometheus.MustNewConstMetric(mcWorldChunksDesc, prometheus.GaugeValue, float64(stat.Chunks), labels...)
+		ch <- prometheus.MustNewConstHistogram(mcWorldChunkSizeDesc, uint64(stat.Chunks), stat.ChunkSizeSum, stat.ChunkSizeBuckets, labels...)
+	}
+}
+
+// Collect the state of the world from level.dat.
+// Newer versions moved some of the data out of level.dat, these metrics are skipped.
+func (c *WorldCollector) collectLevelData(ch chan<- prometheus.Metric) {
+	level, err := c.save.LoadLevelData()
+	if err != nil {
+		slog.Error("Failed to load level.dat", "err", err)
+		return
+	}
+
+	labels := []string{c.Instance}
+
+	ch <- prometheus.MustNewConstMetric(mcWorldGameTimeDesc, prometheus.CounterValue, float64(level.Time), labels...)
+	if level.DayTime != nil {
+		ch <- prometheus.MustNewConstMetric(mcWorldDayTimeDesc, prometheus.GaugeValue, float64(*level.DayTime), labels...)
+	}
+
+	if level.Raining != nil {
+		ch <- prometheus.MustNewConstMetric(mcWorldRainingDesc, prometheus.GaugeValue, boolToFloat(*level.Raining), labels...)
+		ch <- prometheus.MustNewConstMetric(mcWorldRainTimeDesc, prometheus.GaugeValue, float64(level.RainTime), labels...)
+		ch <- prometheus.MustNewConstMetric(mcWorldClearWeatherTimeDesc, prometheus.GaugeValue, float64(level.ClearWeatherTime), labels...)
+	}
+	if level.Thundering != nil {
+		ch <- prometheus.MustNewConstMetric(mcWorldThunderingDesc, prometheus.GaugeValue, boolToFloat(*level.Thundering), labels...)
+		ch <- prometheus.MustNewConstMetric(mcWorldThunderTimeDesc, prometheus.GaugeValue, float64(level.ThunderTime), labels...)
+	}
+
+	if difficulty, ok := level.GetDifficulty(); ok {
+		ch <- prometheus.MustNewConstMetric(mcWorldDifficultyDesc, prometheus.GaugeValue, float64(difficulty), labels...)
+	}
+	ch <- prometheus.MustNewConstMetric(mcWorldHardcoreDesc, prometheus.GaugeValue, boolToFloat(level.IsHardcore()), labels...)
+
+	if level.BorderSize != nil {
+		ch <- prometheus.MustNewConstMetric(mcWorldBorderCenterDesc, prometheus.GaugeValue, level.BorderCenterX, append(labels, "x")...)
+		ch <- prometheus.MustNewConstMetric(mcWorldBorderCenterDesc, prometheus.GaugeValue, level.BorderCenterZ, append(labels, "z")...)
+		ch <- prometheus.MustNewConstMetric(mcWorldBorderSizeDesc, prometheus.GaugeValue, *level.BorderSize, labels...)
+		ch <- prometheus.MustNewConstMetric(mcWorldBorderDamageDesc, prometheus.GaugeValue, level.BorderDamagePerBlock, labels...)
+	}
+
+	if spawn, ok := level.GetSpawn(); ok {
+		ch <- prometheus.MustNewConstMetric(mcWorldSpawnDesc, prometheus.GaugeValue, float64(spawn[0]), append(labels, "x")...)
+		ch <- prometheus.MustNewConstMetric(mcWorldSpawnDesc, prometheus.GaugeValue, float64(spawn[1]), append(labels, "y")...)
+		ch <- prometheus.MustNewConstMetric(mcWorldSpawnDesc, prometheus.GaugeValue, float64(spawn[2]), append(labels, "z")...)
+	}
+
+	if dragon := level.GetDragonFight(); dragon != nil {
+		ch <- prometheus.MustNewConstMetric(mcWorldDragonKilledDesc, prometheus.GaugeValue, boolToFloat(dragon.DragonKilled), labels...)
+		ch <- prometheus.MustNewConstMetric(mcWorldDragonPreviouslyKilledDesc, prometheus.GaugeValue, boolToFloat(dragon.PreviouslyKilled), labels...)
+	}
+
+	for rule, value := range level.GameRules {
+		if f, ok := parseGameRule(value); ok {
+			ch <- prometheus.MustNewConstMetric(mcWorldGameRuleDesc, prometheus.GaugeValue, f, append(labels, rule)...)
+		} else {
+			ch <- prometheus.MustNewConstMetric(mcWorldGameRuleInfoDesc, prometheus.GaugeValue, 1, append(labels, rule, fmt.Sprint(value))...)
+		}
+	}
+
+	for _, pack := range level.DataPacks.Enabled {
+		ch <- prometheus.MustNewConstMetric(mcWorldDataPackDesc, prometheus.GaugeValue, 1, append(labels, pack, "enabled")...)
+	}
+	for _, pack := range level.DataPacks.Disabled {
+		ch <- prometheus.MustNewConstMetric(mcWorldDataPackDesc, prometheus.GaugeValue, 1, append(labels, pack, "disabled")...)
+	}
+}
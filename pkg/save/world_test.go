@@ -0,0 +1,93 @@
+package save
+
+import (
+	"os"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadWorldStats(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	path := newTestWorld(t, "1.20")
+	chunk := map[string]int32{"DataVersion": 3465}
+	writeTestRegion(t, path+REGION_DIR+"/r.0.0.mca", []testChunk{
+		{X: 0, Z: 0, Data: chunk},
+		{X: 1, Z: 0, Data: chunk},
+	})
+	writeTestRegion(t, path+REGION_DIR+"/r.-1.0.mca", []testChunk{
+		{X: 0, Z: 0, Data: chunk},
+	})
+	writeTestRegion(t, path+NETHER_DIR_LEGACY+REGION_DIR+"/r.0.0.mca", []testChunk{
+		{X: 0, Z: 0, Data: chunk},
+	})
+	require.NoError(os.WriteFile(path+NETHER_DIR_LEGACY+REGION_DIR+"/r.0.1.mca", nil, 0644), "Should create empty region")
+	require.NoError(os.WriteFile(path+REGION_DIR+"/r.1.0.mca", make([]byte, 100), 0644), "Should create truncated region")
+
+	s, err := NewSave(path)
+	require.NoError(err, "Should create save")
+
+	stats, err := s.LoadWorldStats()
+	require.NoError(err, "Should load world stats")
+	require.Len(stats, 2, "Should only contain generated dimensions")
+
+	assert.Equal(DIMENSION_OVERWORLD, stats[0].Dimension)
+	assert.Equal(2, stats[0].RegionFiles, "Should skip the truncated region")
+	assert.Equal(3, stats[0].Chunks)
+	assert.Equal(uint64(3), stats[0].ChunkSizeBuckets[1024], "Small chunks should be in the smallest bucket")
+	assert.Greater(stats[0].ChunkSizeSum, float64(0))
+
+	assert.Equal(DIMENSION_THE_NETHER, stats[1].Dimension)
+	assert.Equal(2, stats[1].RegionFiles)
+	assert.Equal(1, stats[1].Chunks)
+	assert.Len(s.worldStats.regions.entries, 4, "Should cache the valid region files")
+
+	require.NoError(os.Remove(path + REGION_DIR + "/r.-1.0.mca"))
+	stats, err = s.LoadWorldStats()
+	require.NoError(err, "Should load world stats")
+	assert.Equal(2, stats[0].Chunks, "Should not return removed regions from the cache")
+	assert.Len(s.worldStats.regions.entries, 3, "Should remove deleted regions from the cache")
+}
+
+func TestWorldCollector(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	c, err := NewWorldCollector("not-a-path", "test-instance")
+	assert.Error(err, "Should not create collector with invalid path")
+	assert.Nil(c, "Collector should be nil on error")
+
+	// Bukkit based servers store the nether as a separate world without player data
+	path := t.TempDir()
+	require.NoError(copyFile("./testdata/1.20/level.dat", path+"/level.dat"))
+	writeTestRegion(t, path+NETHER_DIR_LEGACY+REGION_DIR+"/r.0.0.mca", []testChunk{{Data: map[string]int32{"DataVersion": 3465}}})
+
+	_, err = NewSave(path)
+	assert.Error(err, "Should require player data for a full save")
+	c, err = NewWorldCollector(path, "test-instance")
+	require.NoError(err, "Should create collector without player data")
+
+	reg := prometheus.NewPedanticRegistry()
+	require.NoError(reg.Register(c), "Should register collector")
+
+	families, err := reg.Gather()
+	require.NoError(err, "Collected metrics should match the description")
+
+	names := make([]string, 0, len(families))
+	for _, family := range families {
+		names = append(names, family.GetName())
+		for _, metric := range family.GetMetric() {
+			labels := make(map[string]string, len(metric.GetLabel()))
+			for _, label := range metric.GetLabel() {
+				labels[label.GetName()] = label.GetValue()
+			}
+			assert.Equal("test-instance", labels["instance"], "World metrics should contain the instance label")
+		}
+	}
+	assert.Contains(names, "minecraft_world_chunks_generated")
+	assert.Contains(names, "minecraft_world_game_time_ticks")
+}
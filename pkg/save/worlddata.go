@@ -0,0 +1,210 @@
+package save
+
+import (
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/Tnze/go-mc/nbt"
+	"github.com/heathcliff26/minecraft-exporter/pkg/utils"
+)
+
+const (
+	FORCED_CHUNKS_FILE  = "/chunks.dat"
+	CHUNK_TICKETS_FILE  = "/chunk_tickets.dat"
+	MAP_ID_COUNTS_FILE  = "/idcounts.dat"
+	TICKET_TYPE_FORCED  = "minecraft:forced"
+	RAIDS_FILE_PATTERN  = "raids*.dat"
+	MAPS_FILE_PATTERN   = "map_*.dat"
+	STORAGE_FILE_PREFIX = "command_storage_"
+)
+
+// The content of the data folders of the world
+type WorldData struct {
+	// Number of forceloaded chunks by dimension
+	ForcedChunks map[string]int
+	// Raids that are currently active, by dimension
+	Raids map[string][]Raid
+	// Number of map_*.dat files
+	Maps int
+	// Id of the last created map, -1 if no map has been created
+	LastMapID int
+	// Top level keys of each command storage
+	CommandStorage map[string][]string
+}
+
+// Possible values of the status of a raid
+var raidStatuses = []string{"ongoing", "victory", "loss", "stopped"}
+
+type Raid struct {
+	ID     int    `nbt:"Id"`
+	Active bool   `nbt:"Active"`
+	Status string `nbt:"Status"`
+	// The number of waves that have spawned so far
+	GroupsSpawned int `nbt:"GroupsSpawned"`
+	// The total number of waves of the raid
+	NumGroups int `nbt:"NumGroups"`
+}
+
+type forcedChunksFile struct {
+	Data struct {
+		Forced []int64 `nbt:"Forced"`
+	} `nbt:"data"`
+}
+
+// Since 1.21.5 forced chunks are stored as tickets in chunk_tickets.dat
+type chunkTicketsFile struct {
+	Data struct {
+		Tickets []struct {
+			Type string `nbt:"type"`
+		} `nbt:"tickets"`
+	} `nbt:"data"`
+}
+
+type raidsFile struct {
+	Data struct {
+		Raids []Raid `nbt:"Raids"`
+	} `nbt:"data"`
+}
+
+type mapIDCountsFile struct {
+	Data struct {
+		Map *int `nbt:"map"`
+	} `nbt:"data"`
+}
+
+type commandStorageFile struct {
+	Data struct {
+		Contents map[string]map[string]nbt.RawMessage `nbt:"contents"`
+	} `nbt:"data"`
+}
+
+// Load forced chunks, raids, maps and command storage from the data folders of the world.
+// Unreadable files are skipped, so a single broken file does not hide the rest of the data.
+func (s *Save) LoadWorldData() (WorldData, error) {
+	dimensions := s.GetDimensions()
+	data := WorldData{
+		ForcedChunks:   make(map[string]int, len(dimensions)),
+		Raids:          make(map[string][]Raid, len(dimensions)),
+		LastMapID:      -1,
+		CommandStorage: make(map[string][]string),
+	}
+
+	for _, dim := range dimensions {
+		dir := s.dimensionDataDir(dim)
+
+		forced, err := loadForcedChunks(dir)
+		if err != nil {
+			slog.Warn("Skipping unreadable forced chunks", slog.String("dimension", dim.Name), "err", err)
+		} else {
+			data.ForcedChunks[dim.Name] = forced
+		}
+
+		raids, err := loadRaids(dir)
+		if err != nil {
+			return WorldData{}, err
+		}
+		data.Raids[dim.Name] = raids
+	}
+
+	maps, err := filepath.Glob(filepath.Join(s.dataDir, MAPS_FILE_PATTERN))
+	if err != nil {
+		return WorldData{}, err
+	}
+	data.Maps = len(maps)
+
+	var idcounts mapIDCountsFile
+	err = readNBT(s.dataDir+MAP_ID_COUNTS_FILE, &idcounts)
+	if err != nil && !os.IsNotExist(err) {
+		slog.Warn("Skipping unreadable map id counts", "err", err)
+	} else if idcounts.Data.Map != nil {
+		data.LastMapID = *idcounts.Data.Map
+	}
+
+	storages, err := filepath.Glob(filepath.Join(s.dataDir, STORAGE_FILE_PREFIX+"*.dat"))
+	if err != nil {
+		return WorldData{}, err
+	}
+	for _, path := range storages {
+		namespace := strings.TrimSuffix(strings.TrimPrefix(filepath.Base(path), STORAGE_FILE_PREFIX), ".dat")
+
+		var storage commandStorageFile
+		err = readNBT(path, &storage)
+		if err != nil {
+			slog.Warn("Skipping unreadable command storage", slog.String("path", path), "err", err)
+			continue
+		}
+		for name, content := range storage.Data.Contents {
+			keys := make([]string, 0, len(content))
+			for key := range content {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+			data.CommandStorage[namespace+":"+name] = keys
+		}
+	}
+
+	return data, nil
+}
+
+// Return the data folder of the dimension
+func (s *Save) dimensionDataDir(dim Dimension) string {
+	if utils.VersionGreaterOrEqual(utils.VERSION_26, s.Version.Name) {
+		return dim.Path + DATA_DIR_NAMESPACED
+	}
+	return dim.Path + DATA_DIR
+}
+
+// Count the forceloaded chunks in the data folder of a dimension
+func loadForcedChunks(dir string) (int, error) {
+	var tickets chunkTicketsFile
+	err := readNBT(dir+CHUNK_TICKETS_FILE, &tickets)
+	if err == nil {
+		count := 0
+		for _, ticket := range tickets.Data.Tickets {
+			if ticket.Type == TICKET_TYPE_FORCED {
+				count++
+			}
+		}
+		return count, nil
+	} else if !os.IsNotExist(err) {
+		return 0, err
+	}
+
+	var forced forcedChunksFile
+	err = readNBT(dir+FORCED_CHUNKS_FILE, &forced)
+	if os.IsNotExist(err) {
+		return 0, nil
+	} else if err != nil {
+		return 0, err
+	}
+	return len(forced.Data.Forced), nil
+}
+
+// Read the active raids from the data folder of a dimension.
+// The file name depends on the dimension (raids.dat, raids_nether.dat, raids_end.dat).
+// Unreadable files are skipped.
+func loadRaids(dir string) ([]Raid, error) {
+	files, err := filepath.Glob(filepath.Join(dir, RAIDS_FILE_PATTERN))
+	if err != nil {
+		return nil, err
+	}
+
+	var raids []Raid
+	for _, path := range files {
+		var data raidsFile
+		err = readNBT(path, &data)
+		if err != nil {
+			slog.Warn("Skipping unreadable raids", slog.String("path", path), "err", err)
+			continue
+		}
+		for _, raid := range data.Data.Raids {
+			if raid.Active {
+				raids = append(raids, raid)
+			}
+		}
+	}
+	return raids, nil
+}
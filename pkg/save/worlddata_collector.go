@@ -0,0 +1,100 @@
+package save
+
+import (
+	"log/slog"
+	"slices"
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type WorldDataCollector struct {
+	save     *Save
+	Instance string
+}
+
+var (
+	mcWorldForcedChunksDesc      = prometheus.NewDesc("minecraft_world_forced_chunks", "Number of forceloaded chunks of a dimension", worldVariableLabels, nil)
+	mcWorldRaidWaveDesc          = prometheus.NewDesc("minecraft_world_raid_wave", "Number of waves an active raid has spawned so far", append(worldVariableLabels, "raid"), nil)
+	mcWorldRaidWavesDesc         = prometheus.NewDesc("minecraft_world_raid_waves", "Total number of waves of an active raid", append(worldVariableLabels, "raid"), nil)
+	mcWorldRaidStatusDesc        = prometheus.NewDesc("minecraft_world_raid_status", "Status of an active raid, the current status is 1 and all others are 0", append(worldVariableLabels, "raid", "status"), nil)
+	mcWorldMapsDesc              = prometheus.NewDesc("minecraft_world_maps", "Number of maps stored in the world", levelVariableLabels, nil)
+	mcWorldMapLastIDDesc         = prometheus.NewDesc("minecraft_world_map_last_id", "Id of the last map that has been created", levelVariableLabels, nil)
+	mcWorldCommandStorageKeyDesc = prometheus.NewDesc("minecraft_world_command_storage_key_info", "Top level keys of the command storages used by datapacks", append(levelVariableLabels, "storage", "key"), nil)
+)
+
+// Create new instance of collector, returns error if an world directory is not provided
+// Arguments:
+//
+//	path: The path of the minecraft world directory
+//	instance: The instance label to use for the metrics
+func NewWorldDataCollector(path, instance string) (*WorldDataCollector, error) {
+	save, err := NewWorldSave(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return &WorldDataCollector{
+		save:     save,
+		Instance: instance,
+	}, nil
+}
+
+// Implements the Describe function for prometheus.Collector
+func (c *WorldDataCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- mcWorldForcedChunksDesc
+	ch <- mcWorldRaidWaveDesc
+	ch <- mcWorldRaidWavesDesc
+	ch <- mcWorldRaidStatusDesc
+	ch <- mcWorldMapsDesc
+	ch <- mcWorldMapLastIDDesc
+	ch <- mcWorldCommandStorageKeyDesc
+}
+
+// Implements the Collect function for prometheus.Collector
+func (c *WorldDataCollector) Collect(ch chan<- prometheus.Metric) {
+	slog.Debug("Starting collection of world data")
+
+	data, err := c.save.LoadWorldData()
+	if err != nil {
+		slog.Error("Failed to load the data folder of the world", "err", err)
+		return
+	}
+
+	for dim, count := range data.ForcedChunks {
+		ch <- prometheus.MustNewConstMetric(mcWorldForcedChunksDesc, prometheus.GaugeValue, float64(count), c.Instance, dim)
+	}
+	for dim, raids := range data.Raids {
+		for _, raid := range raids {
+			id := strconv.Itoa(raid.ID)
+			ch <- prometheus.MustNewConstMetric(mcWorldRaidWaveDesc, prometheus.GaugeValue, float64(raid.GroupsSpawned), c.Instance, dim, id)
+			ch <- prometheus.MustNewConstMetric(mcWorldRaidWavesDesc, prometheus.GaugeValue, float64(raid.NumGroups), c.Instance, dim, id)
+
+			// Export every status, so a status change does not start a new series
+			statuses := raidStatuses
+			if !slices.Contains(statuses, raid.Status) {
+				statuses = append(slices.Clone(statuses), raid.Status)
+			}
+			for _, status := range statuses {
+				value := 0.0
+				if status == raid.Status {
+					value = 1
+				}
+				ch <- prometheus.MustNewConstMetric(mcWorldRaidStatusDesc, prometheus.GaugeValue, value, c.Instance, dim, id, status)
+			}
+		}
+	}
+
+	ch <- prometheus.MustNewConstMetric(mcWorldMapsDesc, prometheus.GaugeValue, float64(data.Maps), c.Instance)
+	if data.LastMapID >= 0 {
+		ch <- prometheus.MustNewConstMetric(mcWorldMapLastIDDesc, prometheus.GaugeValue, float64(data.LastMapID), c.Instance)
+	}
+
+	for storage, keys := range data.CommandStorage {
+		for _, key := range keys {
+			ch <- prometheus.MustNewConstMetric(mcWorldCommandStorageKeyDesc, prometheus.GaugeValue, 1, c.Instance, storage, key)
+		}
+	}
+
+	slog.Debug("Finished collection of world data")
+}
@@ -0,0 +1,164 @@
+package save
+
+import (
+	"os"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadWorldData(t *testing.T) {
+	t.Run("Legacy", func(t *testing.T) {
+		assert := assert.New(t)
+		require := require.New(t)
+
+		path := newTestWorld(t, "1.20")
+		require.NoError(os.MkdirAll(path+REGION_DIR, 0755))
+		require.NoError(os.MkdirAll(path+NETHER_DIR_LEGACY+REGION_DIR, 0755))
+		writeTestNBT(t, path+DATA_DIR+FORCED_CHUNKS_FILE, map[string]any{
+			"data": map[string]any{"Forced": []int64{0, 1<<32 | 5, -1}},
+		})
+		writeTestNBT(t, path+DATA_DIR+"/raids.dat", map[string]any{
+			"data": map[string]any{"Raids": []map[string]any{
+				{"Id": int32(3), "Active": int8(1), "Status": "ongoing", "GroupsSpawned": int32(2), "NumGroups": int32(5)},
+				{"Id": int32(1), "Active": int8(0), "Status": "stopped", "GroupsSpawned": int32(1), "NumGroups": int32(5)},
+			}},
+		})
+		writeTestNBT(t, path+NETHER_DIR_LEGACY+DATA_DIR+"/raids_nether.dat", map[string]any{
+			"data": map[string]any{"Raids": []map[string]any{
+				{"Id": int32(4), "Active": int8(1), "Status": "victory", "GroupsSpawned": int32(7), "NumGroups": int32(7)},
+			}},
+		})
+		writeTestNBT(t, path+DATA_DIR+"/map_0.dat", map[string]any{"data": map[string]any{"scale": int8(0)}})
+		writeTestNBT(t, path+DATA_DIR+"/map_1.dat", map[string]any{"data": map[string]any{"scale": int8(0)}})
+		writeTestNBT(t, path+DATA_DIR+MAP_ID_COUNTS_FILE, map[string]any{"data": map[string]any{"map": int32(1)}})
+		writeTestNBT(t, path+DATA_DIR+"/command_storage_eco.dat", map[string]any{
+			"data": map[string]any{"contents": map[string]any{
+				"bank": map[string]any{"balances": []int32{1, 2}, "interest": int32(3)},
+			}},
+		})
+
+		s, err := NewSave(path)
+		require.NoError(err, "Should create save")
+
+		data, err := s.LoadWorldData()
+		require.NoError(err, "Should load world data")
+
+		assert.Equal(map[string]int{DIMENSION_OVERWORLD: 3, DIMENSION_THE_NETHER: 0}, data.ForcedChunks)
+		assert.Equal([]Raid{{ID: 3, Active: true, Status: "ongoing", GroupsSpawned: 2, NumGroups: 5}}, data.Raids[DIMENSION_OVERWORLD], "Should only contain active raids")
+		assert.Equal([]Raid{{ID: 4, Active: true, Status: "victory", GroupsSpawned: 7, NumGroups: 7}}, data.Raids[DIMENSION_THE_NETHER])
+		assert.Equal(2, data.Maps)
+		assert.Equal(1, data.LastMapID)
+		assert.Equal(map[string][]string{"eco:bank": {"balances", "interest"}}, data.CommandStorage)
+	})
+	t.Run("ChunkTickets", func(t *testing.T) {
+		assert := assert.New(t)
+		require := require.New(t)
+
+		path := newTestWorld(t, "26")
+		dir := path + DIMENSIONS_DIR + DIMENSION_DIR_OVERWORLD
+		require.NoError(os.MkdirAll(dir+REGION_DIR, 0755))
+		writeTestNBT(t, dir+DATA_DIR_NAMESPACED+CHUNK_TICKETS_FILE, map[string]any{
+			"data": map[string]any{"tickets": []map[string]any{
+				{"type": TICKET_TYPE_FORCED, "chunk_pos": []int32{0, 0}},
+				{"type": TICKET_TYPE_FORCED, "chunk_pos": []int32{0, 1}},
+				{"type": "minecraft:portal", "chunk_pos": []int32{4, 4}},
+			}},
+		})
+
+		s, err := NewSave(path)
+		require.NoError(err, "Should create save")
+
+		data, err := s.LoadWorldData()
+		require.NoError(err, "Should load world data")
+
+		assert.Equal(map[string]int{DIMENSION_OVERWORLD: 2}, data.ForcedChunks, "Should only count forced tickets")
+		assert.Equal(-1, data.LastMapID, "Should not have created maps")
+	})
+	t.Run("UnreadableFiles", func(t *testing.T) {
+		assert := assert.New(t)
+		require := require.New(t)
+
+		path := newTestWorld(t, "1.20")
+		require.NoError(os.MkdirAll(path+REGION_DIR, 0755))
+		require.NoError(os.MkdirAll(path+NETHER_DIR_LEGACY+REGION_DIR, 0755))
+		require.NoError(os.MkdirAll(path+DATA_DIR, 0755))
+		for _, file := range []string{FORCED_CHUNKS_FILE, "/raids.dat", MAP_ID_COUNTS_FILE, "/command_storage_broken.dat"} {
+			require.NoError(os.WriteFile(path+DATA_DIR+file, []byte("not nbt"), 0644))
+		}
+		writeTestNBT(t, path+NETHER_DIR_LEGACY+DATA_DIR+FORCED_CHUNKS_FILE, map[string]any{
+			"data": map[string]any{"Forced": []int64{0}},
+		})
+		writeTestNBT(t, path+NETHER_DIR_LEGACY+DATA_DIR+"/raids_nether.dat", map[string]any{
+			"data": map[string]any{"Raids": []map[string]any{
+				{"Id": int32(4), "Active": int8(1), "Status": "ongoing", "GroupsSpawned": int32(1), "NumGroups": int32(7)},
+			}},
+		})
+		writeTestNBT(t, path+DATA_DIR+"/command_storage_eco.dat", map[string]any{
+			"data": map[string]any{"contents": map[string]any{
+				"bank": map[string]any{"interest": int32(3)},
+			}},
+		})
+
+		s, err := NewSave(path)
+		require.NoError(err, "Should create save")
+
+		data, err := s.LoadWorldData()
+		require.NoError(err, "Should skip unreadable files")
+
+		assert.Equal(map[string]int{DIMENSION_THE_NETHER: 1}, data.ForcedChunks, "Should skip dimensions with unreadable forced chunks")
+		assert.Empty(data.Raids[DIMENSION_OVERWORLD], "Should skip unreadable raids")
+		assert.Len(data.Raids[DIMENSION_THE_NETHER], 1, "Should load the remaining raids")
+		assert.Equal(-1, data.LastMapID, "Should skip unreadable map id counts")
+		assert.Equal(map[string][]string{"eco:bank": {"interest"}}, data.CommandStorage, "Should skip unreadable command storage")
+	})
+}
+
+func TestWorldDataCollector(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	c, err := NewWorldDataCollector("not-a-path", "test-instance")
+	assert.Error(err, "Should not create collector with invalid path")
+	assert.Nil(c, "Collector should be nil on error")
+
+	path := newTestWorld(t, "1.20")
+	require.NoError(os.MkdirAll(path+REGION_DIR, 0755))
+	writeTestNBT(t, path+DATA_DIR+FORCED_CHUNKS_FILE, map[string]any{
+		"data": map[string]any{"Forced": []int64{0}},
+	})
+	writeTestNBT(t, path+DATA_DIR+"/raids.dat", map[string]any{
+		"data": map[string]any{"Raids": []map[string]any{
+			{"Id": int32(3), "Active": int8(1), "Status": "ongoing", "GroupsSpawned": int32(2), "NumGroups": int32(5)},
+		}},
+	})
+
+	c, err = NewWorldDataCollector(path, "test-instance")
+	require.NoError(err, "Should create collector")
+
+	reg := prometheus.NewPedanticRegistry()
+	require.NoError(reg.Register(c), "Should register collector")
+
+	families, err := reg.Gather()
+	require.NoError(err, "Collected metrics should match the description")
+
+	names := make([]string, 0, len(families))
+	statuses := make(map[string]float64)
+	for _, family := range families {
+		names = append(names, family.GetName())
+		if family.GetName() != "minecraft_world_raid_status" {
+			continue
+		}
+		for _, m := range family.GetMetric() {
+			for _, label := range m.GetLabel() {
+				if label.GetName() == "status" {
+					statuses[label.GetValue()] = m.GetGauge().GetValue()
+				}
+			}
+		}
+	}
+	assert.ElementsMatch([]string{"minecraft_world_forced_chunks", "minecraft_world_raid_wave", "minecraft_world_raid_waves", "minecraft_world_raid_status", "minecraft_world_maps"}, names)
+	assert.Equal(map[string]float64{"ongoing": 1, "victory": 0, "loss": 0, "stopped": 0}, statuses, "Should export every status of the raid")
+}
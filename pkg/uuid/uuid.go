@@ -4,12 +4,17 @@ import (
 	"encoding/json/v2"
 	"log/slog"
 	"net/http"
+	"sync"
 	"time"
 )
 
 type UUIDCache struct {
 	Items     map[string]UUIDCacheItem
 	CacheTime time.Duration
+
+	lock sync.Mutex
+	// Serializes the requests to mojang, to avoid being rate limited
+	fetchLock sync.Mutex
 }
 
 type UUIDCacheItem struct {
@@ -32,18 +37,25 @@ func NewUUIDCache(cacheTime time.Duration) *UUIDCache {
 
 // Either return the name from cache or fetch from the server if the name is either
 // not cached or the cache expired.
-// If the uuid is not known to mojang, return the uuid instead
+// If the uuid is not known to mojang, return the uuid instead.
+// Safe for concurrent use, the requests to mojang are done one at a time without blocking cache hits.
 func (c *UUIDCache) GetNameFromUUID(uuid string) (string, error) {
 	now := time.Now()
 
+	c.lock.Lock()
 	item, ok := c.Items[uuid]
 	if ok {
 		if item.Timestamp.Add(c.CacheTime).After(now) {
+			c.lock.Unlock()
 			return item.Name, nil
 		} else {
 			delete(c.Items, uuid)
 		}
 	}
+	c.lock.Unlock()
+
+	c.fetchLock.Lock()
+	defer c.fetchLock.Unlock()
 
 	res, err := http.Get("https://sessionserver.mojang.com/session/minecraft/profile/" + uuid)
 	if err != nil {
@@ -67,10 +79,12 @@ func (c *UUIDCache) GetNameFromUUID(uuid string) (string, error) {
 		return "", NewErrHttpRequestFailed(res.StatusCode, res.Body)
 	}
 
+	c.lock.Lock()
 	c.Items[uuid] = UUIDCacheItem{
 		Name:      name,
 		Timestamp: now,
 	}
+	c.lock.Unlock()
 
 	return name, nil
 }